@@ -0,0 +1,60 @@
+// Package authz checks the role-based permissions the auth service embeds in
+// access tokens. It builds on the claims jwtauth puts in the context, so the
+// jwtauth interceptor or middleware must run first.
+package authz
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Permissions granted through roles in auth.role_permissions.
+const (
+	PermissionQuestionsWrite = "quiz.questions.write"
+	PermissionPostsModerate  = "community.posts.moderate"
+	PermissionRolesManage    = "auth.roles.manage"
+)
+
+// Built-in roles seeded in auth.roles.
+const (
+	RoleAdmin         = "admin"
+	RoleModerator     = "moderator"
+	RoleContentAuthor = "content_author"
+)
+
+// Has reports whether the authenticated user in ctx holds permission.
+func Has(ctx context.Context, permission string) bool {
+	claims, ok := jwtauth.FromContext(ctx)
+	return ok && slices.Contains(claims.Permissions, permission)
+}
+
+// Require returns a gRPC Unauthenticated error when ctx carries no user and
+// PermissionDenied when the user lacks permission.
+func Require(ctx context.Context, permission string) error {
+	if _, ok := jwtauth.FromContext(ctx); !ok {
+		return status.Error(codes.Unauthenticated, "Authentication required")
+	}
+	if !Has(ctx, permission) {
+		return status.Errorf(codes.PermissionDenied, "Missing permission %s", permission)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor guards the gRPC methods in methods (full method name
+// to required permission); other methods pass through. Chain it after
+// jwtauth.UnaryServerInterceptor.
+func UnaryServerInterceptor(methods map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if permission, ok := methods[info.FullMethod]; ok {
+			if err := Require(ctx, permission); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,40 @@
+package jwtauth
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Middleware verifies the Authorization header of every request not listed
+// with WithPublicMethods and stores the claims in the request context.
+func Middleware(v *Verifier, opts ...Option) func(http.Handler) http.Handler {
+	o := newOptions(opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions || o.isPublic(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := bearerToken(r.Header.Get("Authorization"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := v.Verify(token)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err := o.checkSession(r.Context(), claims); errors.Is(err, ErrSessionRevoked) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			} else if err != nil {
+				http.Error(w, "cannot check session", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+		})
+	}
+}
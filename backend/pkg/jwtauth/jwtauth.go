@@ -0,0 +1,133 @@
+// Package jwtauth verifies the access tokens issued by the auth service and
+// carries the authenticated user through request contexts.
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+type Claims struct {
+	UserID    string
+	SessionID string
+	Role      string
+	Email     string
+	Nickname  string
+
+	// AccountType is AccountTypeStandard or AccountTypeChild; tokens issued
+	// before child accounts existed leave it empty.
+	AccountType  string
+	Restrictions []string
+
+	// Roles and Permissions come from the auth service's RBAC tables; see
+	// package authz for checking them.
+	Roles       []string
+	Permissions []string
+}
+
+type tokenClaims struct {
+	SessionID    string   `json:"sid"`
+	Role         string   `json:"role"`
+	Email        string   `json:"email"`
+	Nickname     string   `json:"nickname"`
+	AvatarEmoji  string   `json:"avatarEmoji"`
+	AccountType  string   `json:"accountType"`
+	Restrictions []string `json:"restrictions"`
+	Roles        []string `json:"roles"`
+	Permissions  []string `json:"permissions"`
+	jwt.RegisteredClaims
+}
+
+type Verifier struct {
+	secret []byte
+	keys   KeySource
+}
+
+// NewVerifier accepts HS256 tokens signed with a shared secret.
+func NewVerifier(secret []byte) *Verifier {
+	return &Verifier{secret: secret}
+}
+
+// NewKeySetVerifier accepts RS256 and EdDSA tokens whose kid header resolves
+// to a key in keys.
+func NewKeySetVerifier(keys KeySource) *Verifier {
+	return &Verifier{keys: keys}
+}
+
+// Verify checks the signature and expiry of tokenString and returns its
+// claims. Tokens without an exp or sub claim are rejected.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	var tc tokenClaims
+	token, err := jwt.ParseWithClaims(tokenString, &tc, v.keyFunc,
+		jwt.WithValidMethods(v.methods()), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid || tc.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &Claims{
+		UserID:       tc.Subject,
+		SessionID:    tc.SessionID,
+		Role:         tc.Role,
+		Email:        tc.Email,
+		Nickname:     tc.Nickname,
+		AccountType:  tc.AccountType,
+		Restrictions: tc.Restrictions,
+		Roles:        tc.Roles,
+		Permissions:  tc.Permissions,
+	}, nil
+}
+
+func (v *Verifier) methods() []string {
+	if v.keys != nil {
+		return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	}
+	return []string{jwt.SigningMethodHS256.Alg()}
+}
+
+func (v *Verifier) keyFunc(t *jwt.Token) (interface{}, error) {
+	if v.keys == nil {
+		return v.secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid header")
+	}
+	return v.keys.PublicKey(kid)
+}
+
+func bearerToken(header string) (string, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(token), nil
+}
+
+type claimsKey struct{}
+
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// UserID returns the authenticated user's id, or false when the request was
+// not authenticated.
+func UserID(ctx context.Context) (string, bool) {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return claims.UserID, true
+}
@@ -0,0 +1,120 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// KeySource resolves the public key a token was signed with from its kid.
+type KeySource interface {
+	PublicKey(kid string) (crypto.PublicKey, error)
+}
+
+// StaticKeySet is a fixed set of verification keys, e.g. loaded from files.
+type StaticKeySet struct {
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+}
+
+func NewStaticKeySet() *StaticKeySet {
+	return &StaticKeySet{keys: make(map[string]crypto.PublicKey)}
+}
+
+// Add registers pub under its RFC 7638 thumbprint and returns that kid.
+func (s *StaticKeySet) Add(pub crypto.PublicKey) (string, error) {
+	kid, err := KeyID(pub)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = pub
+	return kid, nil
+}
+
+func (s *StaticKeySet) PublicKey(kid string) (crypto.PublicKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pub, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return pub, nil
+}
+
+// JWKS returns every key in the set in JSON Web Key Set form.
+func (s *StaticKeySet) JWKS() (*JWKSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := &JWKSet{Keys: []JWK{}}
+	for kid, pub := range s.keys {
+		jwk, err := NewJWK(kid, pub)
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, *jwk)
+	}
+	return set, nil
+}
+
+// LoadPublicKeyFile reads an RSA or Ed25519 key from a PEM file. Private keys
+// are accepted too and reduced to their public half.
+func LoadPublicKeyFile(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		priv, err := ParsePrivateKeyPEM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return priv.Public(), nil
+	}
+}
+
+// ParsePrivateKeyPEM decodes an RSA (PKCS#1 or PKCS#8) or Ed25519 (PKCS#8)
+// private key.
+func ParsePrivateKeyPEM(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return k, nil
+		case ed25519.PrivateKey:
+			return k, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
@@ -0,0 +1,44 @@
+package jwtauth
+
+import "strings"
+
+type Option func(*options)
+
+type options struct {
+	public   []string
+	sessions SessionChecker
+}
+
+// WithPublicMethods lets the listed gRPC full method names (or HTTP paths)
+// through without a token. An entry ending in "/" matches every method of
+// that service, e.g. "/grpc.health.v1.Health/".
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) {
+		o.public = append(o.public, methods...)
+	}
+}
+
+// WithSessionChecker rejects tokens whose session has been revoked, e.g. by
+// logout or from the auth service's session list.
+func WithSessionChecker(c SessionChecker) Option {
+	return func(o *options) {
+		o.sessions = c
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) isPublic(method string) bool {
+	for _, p := range o.public {
+		if method == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(method, p)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package jwtauth
+
+import (
+	"context"
+	"slices"
+)
+
+// Account types carried in the accountType claim.
+const (
+	AccountTypeStandard = "standard"
+	AccountTypeChild    = "child"
+)
+
+// Restrictions a guardian can place on a child account. Each names a feature
+// the account may not use; services check them with Restricted before acting.
+const (
+	RestrictionCommunityPosting  = "community_posting"
+	RestrictionCommunityComments = "community_comments"
+	RestrictionPurchases         = "purchases"
+	RestrictionVideoUpload       = "video_upload"
+)
+
+// AllRestrictions lists every restriction the auth service knows about.
+var AllRestrictions = []string{
+	RestrictionCommunityPosting,
+	RestrictionCommunityComments,
+	RestrictionPurchases,
+	RestrictionVideoUpload,
+}
+
+func (c *Claims) IsChild() bool {
+	return c.AccountType == AccountTypeChild
+}
+
+func (c *Claims) HasRestriction(restriction string) bool {
+	return slices.Contains(c.Restrictions, restriction)
+}
+
+// Restricted reports whether the authenticated user in ctx is barred from
+// restriction. Unauthenticated requests are not restricted; services that
+// need a user reject those separately.
+func Restricted(ctx context.Context, restriction string) bool {
+	claims, ok := FromContext(ctx)
+	return ok && claims.HasRestriction(restriction)
+}
@@ -0,0 +1,166 @@
+package jwtauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrSessionRevoked = errors.New("session revoked")
+
+// SessionChecker reports whether the session (the sid claim) a token belongs
+// to has been signed out. Access tokens are otherwise valid until they
+// expire, so without a checker a revoked session keeps working for up to the
+// token lifetime.
+type SessionChecker interface {
+	SessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// checkSession rejects claims whose session has been revoked. Tokens without
+// a sid predate sessions and are let through.
+func (o *options) checkSession(ctx context.Context, claims *Claims) error {
+	if o.sessions == nil || claims.SessionID == "" {
+		return nil
+	}
+	revoked, err := o.sessions.SessionRevoked(ctx, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// RevocationList is a SessionChecker for services other than auth. It polls
+// the auth service's /sessions/revoked endpoint, which lists the sessions
+// revoked within the last access token lifetime, and keeps serving the last
+// good list if a poll fails. Polls run in the background, one at a time, so
+// requests only ever wait for the very first list.
+type RevocationList struct {
+	url        string
+	client     *http.Client
+	interval   time.Duration
+	retryAfter time.Duration
+
+	mu        sync.RWMutex
+	revoked   map[string]struct{}
+	loaded    bool
+	nextFetch time.Time
+	lastErr   error
+	// fetching is closed when the running poll ends; nil when none is.
+	fetching chan struct{}
+}
+
+func NewRevocationList(url string) *RevocationList {
+	return &RevocationList{
+		url:        url,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		interval:   10 * time.Second,
+		retryAfter: 5 * time.Second,
+		revoked:    make(map[string]struct{}),
+	}
+}
+
+func (l *RevocationList) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	l.mu.RLock()
+	loaded, due := l.loaded, !time.Now().Before(l.nextFetch)
+	_, revoked := l.revoked[sessionID]
+	l.mu.RUnlock()
+
+	if !due {
+		if !loaded {
+			return false, l.err()
+		}
+		return revoked, nil
+	}
+	done := l.poll()
+	if loaded {
+		return revoked, nil
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.loaded {
+		return false, l.lastErr
+	}
+	_, revoked = l.revoked[sessionID]
+	return revoked, nil
+}
+
+func (l *RevocationList) err() error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.lastErr
+}
+
+// poll starts a refresh unless one is running and returns a channel closed
+// when it ends.
+func (l *RevocationList) poll() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fetching == nil {
+		l.fetching = make(chan struct{})
+		go l.refreshInBackground(l.fetching)
+	}
+	return l.fetching
+}
+
+// refreshInBackground refreshes the list, or after a failure holds off
+// polling again for retryAfter so a down auth service is not hammered.
+func (l *RevocationList) refreshInBackground(done chan struct{}) {
+	revoked, err := l.refresh(context.Background())
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		l.lastErr = err
+		l.nextFetch = time.Now().Add(l.retryAfter)
+	} else {
+		l.revoked = revoked
+		l.loaded = true
+		l.lastErr = nil
+		l.nextFetch = time.Now().Add(l.interval)
+	}
+	l.fetching = nil
+	close(done)
+}
+
+func (l *RevocationList) refresh(ctx context.Context) (map[string]struct{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: %s", l.url, resp.Status)
+	}
+
+	var body struct {
+		SessionIDs []string `json:"session_ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	revoked := make(map[string]struct{}, len(body.SessionIDs))
+	for _, id := range body.SessionIDs {
+		revoked[id] = struct{}{}
+	}
+	return revoked, nil
+}
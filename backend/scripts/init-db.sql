@@ -8,15 +8,174 @@ CREATE TABLE auth.users (
     nickname VARCHAR(100) NOT NULL,
     avatar_emoji VARCHAR(10) NOT NULL,
     subscription_type VARCHAR(20) DEFAULT 'free',
+    -- the profile's copy of quiz.user_stats.coins, kept up to date from
+    -- quiz.coin_balance_changed events; coins_version is the version of the
+    -- balance copied, so a late event cannot overwrite a newer one
     coins INTEGER DEFAULT 0,
+    coins_version BIGINT NOT NULL DEFAULT 0,
     level INTEGER DEFAULT 1,
     level_title VARCHAR(100) DEFAULT '초보 탐정',
     xp INTEGER DEFAULT 0,
+    email_verified_at TIMESTAMP,
+    birth_year INTEGER,
+    account_type VARCHAR(20) NOT NULL DEFAULT 'standard',
+    guardian_email VARCHAR(255),
+    guardian_confirmed_at TIMESTAMP,
+    restrictions TEXT[] NOT NULL DEFAULT '{}',
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW()
 );
 
 CREATE INDEX idx_users_email ON auth.users(email);
+CREATE UNIQUE INDEX idx_users_nickname ON auth.users(LOWER(nickname));
+
+CREATE TABLE auth.refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    replaced_by UUID,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON auth.refresh_tokens(family_id);
+
+-- user_id is cleared when the user deletes their account; the revoked row
+-- stays so the revocation is still published to other services.
+CREATE TABLE auth.sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    device VARCHAR(100) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_seen_at TIMESTAMP DEFAULT NOW(),
+    revoked_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_sessions_user_id ON auth.sessions(user_id);
+CREATE INDEX idx_sessions_revoked_at ON auth.sessions(revoked_at) WHERE revoked_at IS NOT NULL;
+
+CREATE TABLE auth.account_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    purpose VARCHAR(20) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_account_tokens_user_id ON auth.account_tokens(user_id, purpose);
+
+CREATE TABLE auth.login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE auth.user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_login_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (provider, subject),
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user_id ON auth.user_identities(user_id);
+
+CREATE TABLE auth.oidc_states (
+    state_hash VARCHAR(64) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- last_used_step stops a TOTP code from being accepted twice.
+CREATE TABLE auth.totp_credentials (
+    user_id UUID PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE auth.recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON auth.recovery_codes(user_id);
+
+-- Events written in the same transaction as the change they report and
+-- published by the auth service's outbox relay.
+CREATE TABLE auth.event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_event_outbox_pending ON auth.event_outbox(id) WHERE published_at IS NULL;
+
+CREATE TABLE auth.roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE auth.permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE auth.role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES auth.roles(name) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES auth.permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE auth.user_roles (
+    user_id UUID NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE,
+    CONSTRAINT user_roles_role_fkey FOREIGN KEY (role) REFERENCES auth.roles(name) ON DELETE CASCADE
+);
+
+INSERT INTO auth.roles (name, description) VALUES
+('admin', '전체 관리자'),
+('moderator', '커뮤니티 게시글 관리'),
+('content_author', '퀴즈 문제 출제');
+
+INSERT INTO auth.permissions (name, description) VALUES
+('auth.roles.manage', '사용자 역할 부여/회수'),
+('community.posts.moderate', '게시글 숨김/삭제'),
+('quiz.questions.write', '퀴즈 문제 작성/수정');
+
+INSERT INTO auth.role_permissions (role, permission) VALUES
+('admin', 'auth.roles.manage'),
+('admin', 'community.posts.moderate'),
+('admin', 'quiz.questions.write'),
+('moderator', 'community.posts.moderate'),
+('content_author', 'quiz.questions.write');
 
 -- Quiz Service Schema
 CREATE SCHEMA IF NOT EXISTS quiz;
@@ -29,7 +188,25 @@ CREATE TABLE quiz.questions (
     correct_index INTEGER NOT NULL,
     explanation TEXT NOT NULL,
     difficulty VARCHAR(20) NOT NULL,
-    created_at TIMESTAMP DEFAULT NOW()
+    -- kind of manipulation shown (face_swap, lip_sync, ...) or 'authentic'
+    category VARCHAR(30) NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    -- draft -> published -> archived; only published questions are served
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    created_by UUID,
+    -- stable key from question bank files; imports upsert on it. Questions
+    -- created without one get their id, so every question can be exported
+    -- and imported back.
+    external_key VARCHAR(100) NOT NULL UNIQUE,
+    -- Elo-style difficulty, starting from the difficulty label and adjusted
+    -- by every answer
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1000,
+    rated_answers INTEGER NOT NULL DEFAULT 0,
+    -- uniform pick position; GetRandomQuestion seeks to a random value on
+    -- the index instead of sorting the pool
+    random_key DOUBLE PRECISION NOT NULL DEFAULT random(),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
 );
 
 CREATE TABLE quiz.user_answers (
@@ -40,6 +217,9 @@ CREATE TABLE quiz.user_answers (
     is_correct BOOLEAN NOT NULL,
     xp_earned INTEGER DEFAULT 0,
     coins_earned INTEGER DEFAULT 0,
+    -- the question's difficulty and category when answered, for stats
+    difficulty VARCHAR(20) NOT NULL DEFAULT '',
+    category VARCHAR(30) NOT NULL DEFAULT '',
     answered_at TIMESTAMP DEFAULT NOW(),
     FOREIGN KEY (question_id) REFERENCES quiz.questions(id)
 );
@@ -51,11 +231,114 @@ CREATE TABLE quiz.user_stats (
     current_streak INTEGER DEFAULT 0,
     best_streak INTEGER DEFAULT 0,
     lives INTEGER DEFAULT 3,
+    -- lost lives regenerate from here (UTC); the count is brought up to
+    -- date when read instead of by a job
+    lives_updated_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    -- skill on the same scale as quiz.questions.rating
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1000,
+    rated_answers INTEGER NOT NULL DEFAULT 0,
+    -- the coin balance, owned by the quiz service; new players start with
+    -- the 100 coins a new account is shown. coins_version goes up with every
+    -- change and is sent with quiz.coin_balance_changed.
+    coins INTEGER NOT NULL DEFAULT 100 CHECK (coins >= 0),
+    coins_version BIGINT NOT NULL DEFAULT 0,
     updated_at TIMESTAMP DEFAULT NOW()
 );
 
-CREATE INDEX idx_user_answers_user_id ON quiz.user_answers(user_id);
+-- One row per question served to a player; SubmitAnswer must quote an
+-- unanswered, unexpired attempt for the same user and question.
+CREATE TABLE quiz.question_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    question_id UUID NOT NULL REFERENCES quiz.questions(id),
+    served_at TIMESTAMP DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    answered_at TIMESTAMP
+);
+
+-- SubmitAnswer responses by idempotency key, so a retried submit gets the
+-- original response back instead of being counted twice.
+CREATE TABLE quiz.answer_submissions (
+    user_id UUID NOT NULL,
+    idempotency_key VARCHAR(100) NOT NULL,
+    question_id UUID NOT NULL,
+    -- serialized SubmitAnswerResponse
+    response BYTEA,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+-- Spaced repetition of missed questions: a miss puts the question in box 1,
+-- each correct review once due moves it up a box with a longer wait.
+CREATE TABLE quiz.review_schedule (
+    user_id UUID NOT NULL,
+    question_id UUID NOT NULL REFERENCES quiz.questions(id),
+    box INTEGER NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, question_id)
+);
+
+-- Timed challenge sessions. The current question's served_at is stamped
+-- by the database so answer times do not depend on any client's clock.
+-- Finished sessions are kept as results for leaderboards.
+CREATE TABLE quiz.timed_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    -- active, finished or abandoned
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    -- empty when questions are matched to the player's rating
+    difficulty VARCHAR(20) NOT NULL DEFAULT '',
+    question_count INTEGER NOT NULL,
+    answered_count INTEGER NOT NULL DEFAULT 0,
+    correct_count INTEGER NOT NULL DEFAULT 0,
+    score INTEGER NOT NULL DEFAULT 0,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    current_question_id UUID REFERENCES quiz.questions(id),
+    question_served_at TIMESTAMP,
+    -- paid when the session finishes, the coins to quiz.user_stats.coins
+    xp_earned INTEGER NOT NULL DEFAULT 0,
+    coins_earned INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE quiz.timed_session_answers (
+    session_id UUID NOT NULL REFERENCES quiz.timed_sessions(id),
+    question_id UUID NOT NULL REFERENCES quiz.questions(id),
+    selected_index INTEGER NOT NULL,
+    is_correct BOOLEAN NOT NULL,
+    timed_out BOOLEAN NOT NULL,
+    elapsed_ms BIGINT NOT NULL,
+    points INTEGER NOT NULL,
+    answered_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (session_id, question_id)
+);
+
+CREATE INDEX idx_user_answers_user_id ON quiz.user_answers(user_id, answered_at DESC);
 CREATE INDEX idx_user_answers_question_id ON quiz.user_answers(question_id);
+CREATE INDEX idx_question_attempts_user_id ON quiz.question_attempts(user_id);
+CREATE INDEX idx_review_schedule_user_due ON quiz.review_schedule(user_id, due_at);
+CREATE INDEX idx_questions_status_difficulty ON quiz.questions(status, difficulty, random_key);
+CREATE INDEX idx_questions_status_random_key ON quiz.questions(status, random_key);
+CREATE INDEX idx_questions_status_rating ON quiz.questions(status, rating);
+CREATE INDEX idx_timed_sessions_user_status ON quiz.timed_sessions(user_id, status);
+CREATE INDEX idx_timed_sessions_leaderboard ON quiz.timed_sessions(finished_at, score DESC) WHERE status = 'finished';
+
+-- Events written in the same transaction as the change they report and
+-- published by the quiz service's outbox relay.
+CREATE TABLE quiz.event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_quiz_event_outbox_pending ON quiz.event_outbox(id) WHERE published_at IS NULL;
 
 -- Community Service Schema
 CREATE SCHEMA IF NOT EXISTS community;
@@ -155,7 +438,15 @@ CREATE INDEX idx_subscriptions_user_id ON payment.subscriptions(user_id);
 CREATE INDEX idx_transactions_user_id ON payment.transactions(user_id);
 
 -- Insert sample data
-INSERT INTO quiz.questions (video_url, thumbnail_emoji, options, correct_index, explanation, difficulty) VALUES
-('https://example.com/video1.mp4', '🐶', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 1, '이 영상은 AI로 생성된 딥페이크입니다. 눈 깜빡임 패턴이 부자연스럽습니다.', 'easy'),
-('https://example.com/video2.mp4', '🐱', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 0, '이 영상은 실제 촬영된 영상입니다.', 'medium'),
-('https://example.com/video3.mp4', '🐰', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 1, '얼굴 경계선에서 미세한 왜곡이 발견됩니다.', 'hard');
+-- Demo account: detective@deepfind.io / password123
+INSERT INTO auth.users (email, password_hash, nickname, avatar_emoji, subscription_type, coins, level, level_title, xp, email_verified_at) VALUES
+('detective@deepfind.io', '$2a$10$v5G7oyXzDuyIx2XxJJ14q.RVVJr8gtvQA2IHpV/dZPxtYZJbQuYDm', '탐정', '🦊', 'free', 1200, 5, '베테랑 탐정', 450, NOW());
+
+INSERT INTO quiz.questions (external_key, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty, category, status, rating) VALUES
+('sample-1', 'https://example.com/video1.mp4', '🐶', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 1, '이 영상은 AI로 생성된 딥페이크입니다. 눈 깜빡임 패턴이 부자연스럽습니다.', 'easy', 'synthetic', 'published', 850),
+('sample-2', 'https://example.com/video2.mp4', '🐱', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 0, '이 영상은 실제 촬영된 영상입니다.', 'medium', 'authentic', 'published', 1000),
+('sample-3', 'https://example.com/video3.mp4', '🐰', ARRAY['진짜 영상', '딥페이크', '편집된 영상', '잘 모르겠음'], 1, '얼굴 경계선에서 미세한 왜곡이 발견됩니다.', 'hard', 'face_swap', 'published', 1150);
+
+-- The demo account's coins start in the quiz service, which owns balances.
+INSERT INTO quiz.user_stats (user_id, coins)
+SELECT id, coins FROM auth.users WHERE email = 'detective@deepfind.io';
@@ -1,5 +1,29 @@
-module auth-service
+module github.com/pawfiler/backend/services/auth
 
-go 1.22
+go 1.23
 
-require github.com/golang-jwt/jwt/v5 v5.2.0
+require (
+	github.com/coreos/go-oidc/v3 v3.11.0
+	github.com/golang-jwt/jwt/v5 v5.2.0
+	github.com/google/uuid v1.6.0
+	github.com/lib/pq v1.10.9
+	github.com/pawfiler/backend/pkg v0.0.0
+	github.com/rivo/uniseg v0.4.7
+	github.com/segmentio/kafka-go v0.4.47
+	golang.org/x/crypto v0.32.0
+	golang.org/x/net v0.34.0
+	golang.org/x/oauth2 v0.24.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241202173237-19429a94021a
+	google.golang.org/grpc v1.70.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/go-jose/go-jose/v4 v4.0.5 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
+
+replace github.com/pawfiler/backend/pkg => ../../pkg
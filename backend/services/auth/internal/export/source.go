@@ -0,0 +1,100 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxSourceSize bounds how much one service may contribute to an export.
+const maxSourceSize = 32 << 20
+
+var sourceName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Source provides one service's part of a user's data export.
+type Source interface {
+	Name() string
+	// Export returns everything the service stores about the user the
+	// access token belongs to, as a JSON document.
+	Export(ctx context.Context, accessToken string) (json.RawMessage, error)
+}
+
+// HTTPSource fetches a service's export endpoint with the user's access
+// token, so the service needs no trust in the caller beyond normal token
+// verification.
+type HTTPSource struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSource(name, url string) *HTTPSource {
+	return &HTTPSource{name: name, url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (s *HTTPSource) Name() string {
+	return s.name
+}
+
+func (s *HTTPSource) Export(ctx context.Context, accessToken string) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s export: unexpected status %s", s.name, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxSourceSize {
+		return nil, fmt.Errorf("%s export: response exceeds %d bytes", s.name, maxSourceSize)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("%s export: response is not JSON", s.name)
+	}
+	return body, nil
+}
+
+// ParseSources reads a comma-separated list of name=url pairs such as
+// "quiz=http://quiz-service:8080/me/export". Names become file names in the
+// archive; "auth" and "manifest" are taken by the auth service.
+func ParseSources(spec string) ([]Source, error) {
+	var sources []Source
+	seen := map[string]bool{"auth": true, "manifest": true}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, url, ok := strings.Cut(entry, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if !ok || url == "" {
+			return nil, fmt.Errorf("export source %q: expected name=url", entry)
+		}
+		if !sourceName.MatchString(name) {
+			return nil, fmt.Errorf("export source %q: invalid name", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate export source %q", name)
+		}
+		seen[name] = true
+		sources = append(sources, NewHTTPSource(name, url))
+	}
+	return sources, nil
+}
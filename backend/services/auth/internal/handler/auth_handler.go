@@ -0,0 +1,540 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/pawfiler/backend/pkg/authz"
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/pawfiler/backend/services/auth/internal/service"
+	pb "github.com/pawfiler/backend/services/auth/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type AuthHandler struct {
+	pb.UnimplementedAuthServiceServer
+	service  *service.AuthService
+	accounts *service.AccountService
+	social   *service.OIDCService
+	privacy  *service.PrivacyService
+	proxies  TrustedProxies
+}
+
+func NewAuthHandler(svc *service.AuthService, accounts *service.AccountService, social *service.OIDCService, privacy *service.PrivacyService, proxies TrustedProxies) *AuthHandler {
+	return &AuthHandler{service: svc, accounts: accounts, social: social, privacy: privacy, proxies: proxies}
+}
+
+func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	tokens, user, err := h.service.Login(ctx, req.Email, req.Password, h.clientInfo(ctx))
+	var challenge *service.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		return &pb.LoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresIn: challenge.ExpiresIn,
+		}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{
+		Token:        tokens.AccessToken,
+		User:         toProfile(user),
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+func (h *AuthHandler) Signup(ctx context.Context, req *pb.SignupRequest) (*pb.SignupResponse, error) {
+	tokens, user, err := h.service.Signup(ctx, req.Email, req.Password, req.Nickname, req.AvatarEmoji, req.BirthYear, req.GuardianEmail, h.clientInfo(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := h.accounts.SendVerificationEmail(ctx, user.ID); err != nil {
+		log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+	}
+	if tokens == nil {
+		if err := h.accounts.SendGuardianConsent(ctx, user.ID); err != nil {
+			log.Printf("failed to send guardian consent email for user %s: %v", user.ID, err)
+		}
+		return &pb.SignupResponse{User: toProfile(user), AwaitingGuardianConsent: true}, nil
+	}
+	return &pb.SignupResponse{
+		Token:        tokens.AccessToken,
+		User:         toProfile(user),
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	tokens, err := h.service.RefreshToken(ctx, req.RefreshToken, h.clientInfo(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if err := h.service.Logout(ctx, req.RefreshToken); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LogoutResponse{}, nil
+}
+
+func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	claims, err := h.service.ValidateToken(ctx, req.Token)
+	if errors.Is(err, service.ErrInvalidToken) {
+		return &pb.ValidateTokenResponse{Valid: false}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ValidateTokenResponse{Valid: true, UserId: claims.UserID}, nil
+}
+
+func (h *AuthHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.UserProfile, error) {
+	userID, err := authorizedUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProfile(user), nil
+}
+
+func (h *AuthHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserProfile, error) {
+	userID, err := authorizedUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.UpdateProfile(ctx, userID, req.Nickname, req.AvatarEmoji)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProfile(user), nil
+}
+
+func (h *AuthHandler) SendVerificationEmail(ctx context.Context, req *pb.SendVerificationEmailRequest) (*pb.SendVerificationEmailResponse, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.accounts.SendVerificationEmail(ctx, userID); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SendVerificationEmailResponse{}, nil
+}
+
+func (h *AuthHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.UserProfile, error) {
+	user, err := h.accounts.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProfile(user), nil
+}
+
+func (h *AuthHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	if err := h.accounts.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	if err := h.accounts.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func (h *AuthHandler) ListOIDCProviders(ctx context.Context, req *pb.ListOIDCProvidersRequest) (*pb.ListOIDCProvidersResponse, error) {
+	return &pb.ListOIDCProvidersResponse{Providers: h.social.Providers()}, nil
+}
+
+func (h *AuthHandler) StartOIDCLogin(ctx context.Context, req *pb.StartOIDCLoginRequest) (*pb.StartOIDCLoginResponse, error) {
+	authURL, state, err := h.social.StartLogin(ctx, req.Provider)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.StartOIDCLoginResponse{AuthorizationUrl: authURL, State: state}, nil
+}
+
+func (h *AuthHandler) CompleteOIDCLogin(ctx context.Context, req *pb.CompleteOIDCLoginRequest) (*pb.CompleteOIDCLoginResponse, error) {
+	tokens, user, created, err := h.social.CompleteLogin(ctx, req.State, req.Code, h.clientInfo(ctx))
+	var challenge *service.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		return &pb.CompleteOIDCLoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresIn: challenge.ExpiresIn,
+		}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if created && user.EmailVerifiedAt == nil {
+		if err := h.accounts.SendVerificationEmail(ctx, user.ID); err != nil {
+			log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+	return &pb.CompleteOIDCLoginResponse{
+		Token:        tokens.AccessToken,
+		User:         toProfile(user),
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		NewUser:      created,
+	}, nil
+}
+
+func (h *AuthHandler) RequestGuardianLink(ctx context.Context, req *pb.RequestGuardianLinkRequest) (*pb.RequestGuardianLinkResponse, error) {
+	if err := h.accounts.RequestGuardianLink(ctx, req.Email); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RequestGuardianLinkResponse{}, nil
+}
+
+func (h *AuthHandler) GetGuardianSettings(ctx context.Context, req *pb.GetGuardianSettingsRequest) (*pb.GuardianSettings, error) {
+	user, err := h.accounts.GuardianSettings(ctx, req.Token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toGuardianSettings(user), nil
+}
+
+func (h *AuthHandler) UpdateGuardianSettings(ctx context.Context, req *pb.UpdateGuardianSettingsRequest) (*pb.GuardianSettings, error) {
+	user, err := h.accounts.UpdateGuardianSettings(ctx, req.Token, req.Restrictions)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toGuardianSettings(user), nil
+}
+
+func (h *AuthHandler) GetUserRoles(ctx context.Context, req *pb.GetUserRolesRequest) (*pb.UserRoles, error) {
+	callerID, ok := jwtauth.UserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Authentication required")
+	}
+	userID := req.UserId
+	if userID == "" {
+		userID = callerID
+	}
+	if userID != callerID {
+		if err := authz.Require(ctx, authz.PermissionRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
+	grants, err := h.service.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUserRoles(userID, grants), nil
+}
+
+func (h *AuthHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.UserRoles, error) {
+	if err := authz.Require(ctx, authz.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+	actorID, _ := jwtauth.UserID(ctx)
+
+	grants, err := h.service.AssignRole(ctx, actorID, req.UserId, req.Role)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUserRoles(req.UserId, grants), nil
+}
+
+func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.UserRoles, error) {
+	if err := authz.Require(ctx, authz.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+	actorID, _ := jwtauth.UserID(ctx)
+
+	grants, err := h.service.RevokeRole(ctx, actorID, req.UserId, req.Role)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUserRoles(req.UserId, grants), nil
+}
+
+func (h *AuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	claims, ok := jwtauth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Authentication required")
+	}
+
+	sessions, err := h.service.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListSessionsResponse{Sessions: make([]*pb.Session, 0, len(sessions))}
+	for _, s := range sessions {
+		resp.Sessions = append(resp.Sessions, &pb.Session{
+			Id:         s.ID,
+			Device:     s.Device,
+			UserAgent:  s.UserAgent,
+			IpAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: s.LastSeenAt.Format(time.RFC3339),
+			Current:    s.ID == claims.SessionID,
+		})
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.RevokeSession(ctx, userID, req.SessionId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+// DeleteAccount deletes the caller's own account once their password checks
+// out.
+func (h *AuthHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.privacy.DeleteAccount(ctx, userID, req.Password, h.clientInfo(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (h *AuthHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.ExportMyDataResponse, error) {
+	claims, ok := jwtauth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Authentication required")
+	}
+
+	export, err := h.privacy.ExportData(ctx, claims.UserID, claims.SessionID, req.Format)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ExportMyDataResponse{
+		Filename:    export.Filename,
+		ContentType: export.ContentType,
+		Data:        export.Data,
+	}, nil
+}
+
+func (h *AuthHandler) CompleteTwoFactorLogin(ctx context.Context, req *pb.CompleteTwoFactorLoginRequest) (*pb.LoginResponse, error) {
+	tokens, user, err := h.service.CompleteTwoFactorLogin(ctx, req.ChallengeToken, req.Code, h.clientInfo(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{
+		Token:        tokens.AccessToken,
+		User:         toProfile(user),
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+func (h *AuthHandler) GetTwoFactorStatus(ctx context.Context, req *pb.GetTwoFactorStatusRequest) (*pb.TwoFactorStatus, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	st, err := h.service.TwoFactorStatus(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.TwoFactorStatus{Enabled: st.Enabled, RecoveryCodesRemaining: int32(st.RecoveryCodesRemaining)}, nil
+}
+
+func (h *AuthHandler) BeginTOTPEnrollment(ctx context.Context, req *pb.BeginTOTPEnrollmentRequest) (*pb.BeginTOTPEnrollmentResponse, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	secret, uri, err := h.service.BeginTOTPEnrollment(ctx, userID, req.Password, h.clientInfo(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.BeginTOTPEnrollmentResponse{Secret: secret, ProvisioningUri: uri}, nil
+}
+
+func (h *AuthHandler) ConfirmTOTPEnrollment(ctx context.Context, req *pb.ConfirmTOTPEnrollmentRequest) (*pb.RecoveryCodes, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	codes, err := h.service.ConfirmTOTPEnrollment(ctx, userID, req.Code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RecoveryCodes{Codes: codes}, nil
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RecoveryCodes, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	codes, err := h.service.RegenerateRecoveryCodes(ctx, userID, req.Code, h.clientInfo(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RecoveryCodes{Codes: codes}, nil
+}
+
+func (h *AuthHandler) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	userID, err := authorizedUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.DisableTwoFactor(ctx, userID, req.Password, req.Code, h.clientInfo(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DisableTwoFactorResponse{}, nil
+}
+
+// authorizedUser resolves the user a profile request acts on. The token's
+// subject wins; a request naming a different user_id is rejected.
+func authorizedUser(ctx context.Context, requested string) (string, error) {
+	userID, ok := jwtauth.UserID(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "Authentication required")
+	}
+	if requested != "" && requested != userID {
+		return "", status.Error(codes.PermissionDenied, "Cannot access another user's profile")
+	}
+	return userID, nil
+}
+
+func toProfile(u *repository.User) *pb.UserProfile {
+	return &pb.UserProfile{
+		Id:               u.ID,
+		Email:            u.Email,
+		Nickname:         u.Nickname,
+		AvatarEmoji:      u.AvatarEmoji,
+		SubscriptionType: u.SubscriptionType,
+		Coins:            u.Coins,
+		Level:            u.Level,
+		LevelTitle:       u.LevelTitle,
+		Xp:               u.XP,
+		CreatedAt:        u.CreatedAt.Format(time.RFC3339),
+		EmailVerified:    u.EmailVerifiedAt != nil,
+		BirthYear:        u.BirthYear,
+		AccountType:      u.AccountType,
+		Restrictions:     u.Restrictions,
+	}
+}
+
+func toUserRoles(userID string, g *repository.Grants) *pb.UserRoles {
+	return &pb.UserRoles{UserId: userID, Roles: g.Roles, Permissions: g.Permissions}
+}
+
+func toGuardianSettings(u *repository.User) *pb.GuardianSettings {
+	return &pb.GuardianSettings{
+		ChildNickname:         u.Nickname,
+		BirthYear:             u.BirthYear,
+		Consented:             u.GuardianConfirmedAt != nil,
+		Restrictions:          u.Restrictions,
+		AvailableRestrictions: jwtauth.AllRestrictions,
+	}
+}
+
+func toStatus(err error) error {
+	var rateLimited *service.RateLimitError
+	if errors.As(err, &rateLimited) {
+		st := status.New(codes.ResourceExhausted, "Too many login attempts, try again later")
+		if detailed, derr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(rateLimited.RetryAfter)}); derr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, "Invalid email or password")
+	case errors.Is(err, service.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, "Invalid token")
+	case errors.Is(err, service.ErrInvalidRefreshToken):
+		return status.Error(codes.Unauthenticated, "Invalid refresh token")
+	case errors.Is(err, service.ErrMissingFields):
+		return status.Error(codes.InvalidArgument, "Email, password, and nickname are required")
+	case errors.Is(err, service.ErrInvalidEmail):
+		return status.Error(codes.InvalidArgument, "Invalid email address")
+	case errors.Is(err, service.ErrPasswordTooShort):
+		return status.Error(codes.InvalidArgument, "Password must be at least 6 characters")
+	case errors.Is(err, service.ErrInvalidNickname):
+		return status.Error(codes.InvalidArgument, "Nickname must be 2 to 20 characters")
+	case errors.Is(err, service.ErrInvalidAvatarEmoji):
+		return status.Error(codes.InvalidArgument, "Avatar must be a single emoji")
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		return status.Error(codes.FailedPrecondition, "Email is already verified")
+	case errors.Is(err, service.ErrInvalidAccountToken):
+		return status.Error(codes.InvalidArgument, "This link is invalid or has expired")
+	case errors.Is(err, service.ErrInvalidBirthYear):
+		return status.Error(codes.InvalidArgument, "Invalid birth year")
+	case errors.Is(err, service.ErrGuardianEmailRequired):
+		return status.Error(codes.InvalidArgument, "A guardian email is required for users under 14")
+	case errors.Is(err, service.ErrInvalidGuardianEmail):
+		return status.Error(codes.InvalidArgument, "Guardian email must be a valid address different from your own")
+	case errors.Is(err, service.ErrGuardianConsentPending):
+		return status.Error(codes.FailedPrecondition, "This account is waiting for guardian consent")
+	case errors.Is(err, service.ErrUnknownRestriction):
+		return status.Error(codes.InvalidArgument, "Unknown restriction")
+	case errors.Is(err, service.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "Session not found")
+	case errors.Is(err, service.ErrIncorrectPassword):
+		return status.Error(codes.PermissionDenied, "Incorrect password")
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		return status.Error(codes.InvalidArgument, "Invalid verification code")
+	case errors.Is(err, service.ErrInvalidChallenge):
+		return status.Error(codes.Unauthenticated, "Sign-in expired, please log in again")
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	case errors.Is(err, service.ErrTwoFactorNotEnabled):
+		return status.Error(codes.FailedPrecondition, "Two-factor authentication is not enabled")
+	case errors.Is(err, service.ErrTwoFactorNotStarted):
+		return status.Error(codes.FailedPrecondition, "Start two-factor setup first")
+	case errors.Is(err, service.ErrUnknownExportFormat):
+		return status.Error(codes.InvalidArgument, "Export format must be zip or json")
+	case errors.Is(err, service.ErrExportUnavailable):
+		return status.Error(codes.Unavailable, "Your data could not be collected right now, please try again later")
+	case errors.Is(err, service.ErrUnknownRole):
+		return status.Error(codes.InvalidArgument, "Unknown role")
+	case errors.Is(err, service.ErrUnknownProvider):
+		return status.Error(codes.InvalidArgument, "Unknown login provider")
+	case errors.Is(err, service.ErrInvalidOIDCState):
+		return status.Error(codes.InvalidArgument, "Login session expired, please try again")
+	case errors.Is(err, service.ErrOIDCLoginFailed):
+		return status.Error(codes.Unauthenticated, "Social login failed")
+	case errors.Is(err, service.ErrOIDCEmailRequired):
+		return status.Error(codes.FailedPrecondition, "Please allow access to your email address to sign in")
+	case errors.Is(err, service.ErrOIDCAccountExists):
+		return status.Error(codes.AlreadyExists, "An account with this email already exists, sign in with your password")
+	case errors.Is(err, repository.ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, "Email is already registered")
+	case errors.Is(err, repository.ErrNicknameTaken):
+		return status.Error(codes.AlreadyExists, "Nickname is already taken")
+	case errors.Is(err, repository.ErrUserNotFound):
+		return status.Error(codes.NotFound, "User not found")
+	default:
+		log.Printf("auth request failed: %v", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
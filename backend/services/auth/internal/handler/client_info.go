@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/pawfiler/backend/services/auth/internal/service"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TrustedProxies are the addresses allowed to tell us the client's IP in
+// X-Forwarded-For. Anyone else could send a new value each time to get a
+// fresh login throttle key.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies reads a comma-separated list of IPs and CIDR ranges.
+func ParseTrustedProxies(list string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) trusts(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+type clientInfoKey struct{}
+
+// withClientInfo records the caller's device for requests arriving through
+// the JSON gateway, where there is no gRPC peer or metadata.
+func withClientInfo(ctx context.Context, r *http.Request, proxies TrustedProxies) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, service.ClientInfo{IP: proxies.httpClientIP(r), UserAgent: r.UserAgent()})
+}
+
+func (h *AuthHandler) clientInfo(ctx context.Context) service.ClientInfo {
+	if info, ok := ctx.Value(clientInfoKey{}).(service.ClientInfo); ok {
+		return info
+	}
+	info := service.ClientInfo{IP: h.proxies.clientIP(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			info.UserAgent = ua[0]
+		}
+	}
+	return info
+}
+
+// clientIP returns the caller's address for login throttling. When the peer
+// is a trusted proxy such as Envoy, the right-most X-Forwarded-For entry is
+// the one it appended, so earlier entries supplied by the client are
+// ignored.
+func (p TrustedProxies) clientIP(ctx context.Context) string {
+	var peerIP string
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		peerIP = hostOnly(pr.Addr.String())
+	}
+	if p.trusts(peerIP) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ip := lastForwarded(md.Get("x-forwarded-for")); ip != "" {
+				return ip
+			}
+		}
+	}
+	return peerIP
+}
+
+func (p TrustedProxies) httpClientIP(r *http.Request) string {
+	remote := hostOnly(r.RemoteAddr)
+	if p.trusts(remote) {
+		if ip := lastForwarded(r.Header.Values("X-Forwarded-For")); ip != "" {
+			return ip
+		}
+	}
+	return remote
+}
+
+// lastForwarded returns the right-most address in X-Forwarded-For. A
+// client can send the header more than once, so every value counts, in
+// order, as if they were one comma-separated list.
+func lastForwarded(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	parts := strings.Split(strings.Join(values, ","), ",")
+	return strings.TrimSpace(parts[len(parts)-1])
+}
+
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
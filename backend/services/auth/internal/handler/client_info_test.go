@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("172.28.0.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct", "203.0.113.5:4000", nil, "203.0.113.5"},
+		{"untrusted peer ignores header", "203.0.113.5:4000", []string{"198.51.100.1"}, "203.0.113.5"},
+		{"trusted proxy", "172.28.0.10:4000", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"right-most entry", "172.28.0.10:4000", []string{"10.9.9.9, 198.51.100.1"}, "198.51.100.1"},
+		{"repeated header", "172.28.0.10:4000", []string{"198.51.100.1", "10.9.9.9"}, "10.9.9.9"},
+		{"trusted proxy without header", "172.28.0.10:4000", nil, "172.28.0.10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/auth/login", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if got := proxies.httpClientIP(r); got != tt.want {
+				t.Fatalf("httpClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
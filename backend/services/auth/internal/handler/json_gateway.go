@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "github.com/pawfiler/backend/services/auth/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	jsonMarshal   = protojson.MarshalOptions{EmitUnpopulated: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// maxRequestBody caps JSON request bodies; no AuthService request comes
+// anywhere near it.
+const maxRequestBody = 1 << 20
+
+// NewJSONGateway exposes every AuthService RPC as a Connect-style JSON
+// endpoint (POST /auth.AuthService/<Method>) for the browser, plus the
+// legacy /login, /signup, /refresh and /logout paths. X-Forwarded-For is
+// only believed from proxies.
+func NewJSONGateway(srv pb.AuthServiceServer, proxies TrustedProxies) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	login := unary(proxies, func(ctx context.Context, req *pb.LoginRequest) (proto.Message, error) { return srv.Login(ctx, req) })
+	signup := unary(proxies, func(ctx context.Context, req *pb.SignupRequest) (proto.Message, error) { return srv.Signup(ctx, req) })
+	refresh := unary(proxies, func(ctx context.Context, req *pb.RefreshTokenRequest) (proto.Message, error) {
+		return srv.RefreshToken(ctx, req)
+	})
+	logout := unary(proxies, func(ctx context.Context, req *pb.LogoutRequest) (proto.Message, error) { return srv.Logout(ctx, req) })
+
+	mux.Handle("/login", login)
+	mux.Handle("/signup", signup)
+	mux.Handle("/refresh", refresh)
+	mux.Handle("/logout", logout)
+	mux.Handle(pb.AuthService_Login_FullMethodName, login)
+	mux.Handle(pb.AuthService_Signup_FullMethodName, signup)
+	mux.Handle(pb.AuthService_RefreshToken_FullMethodName, refresh)
+	mux.Handle(pb.AuthService_Logout_FullMethodName, logout)
+	mux.Handle(pb.AuthService_ValidateToken_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ValidateTokenRequest) (proto.Message, error) {
+		return srv.ValidateToken(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_GetProfile_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.GetProfileRequest) (proto.Message, error) {
+		return srv.GetProfile(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_UpdateProfile_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.UpdateProfileRequest) (proto.Message, error) {
+		return srv.UpdateProfile(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_SendVerificationEmail_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.SendVerificationEmailRequest) (proto.Message, error) {
+		return srv.SendVerificationEmail(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_VerifyEmail_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.VerifyEmailRequest) (proto.Message, error) {
+		return srv.VerifyEmail(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_RequestPasswordReset_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.RequestPasswordResetRequest) (proto.Message, error) {
+		return srv.RequestPasswordReset(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_ResetPassword_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ResetPasswordRequest) (proto.Message, error) {
+		return srv.ResetPassword(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_ListOIDCProviders_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ListOIDCProvidersRequest) (proto.Message, error) {
+		return srv.ListOIDCProviders(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_StartOIDCLogin_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.StartOIDCLoginRequest) (proto.Message, error) {
+		return srv.StartOIDCLogin(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_CompleteOIDCLogin_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.CompleteOIDCLoginRequest) (proto.Message, error) {
+		return srv.CompleteOIDCLogin(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_RequestGuardianLink_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.RequestGuardianLinkRequest) (proto.Message, error) {
+		return srv.RequestGuardianLink(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_GetGuardianSettings_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.GetGuardianSettingsRequest) (proto.Message, error) {
+		return srv.GetGuardianSettings(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_UpdateGuardianSettings_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.UpdateGuardianSettingsRequest) (proto.Message, error) {
+		return srv.UpdateGuardianSettings(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_GetUserRoles_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.GetUserRolesRequest) (proto.Message, error) {
+		return srv.GetUserRoles(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_AssignRole_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.AssignRoleRequest) (proto.Message, error) {
+		return srv.AssignRole(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_RevokeRole_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.RevokeRoleRequest) (proto.Message, error) {
+		return srv.RevokeRole(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_ListSessions_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ListSessionsRequest) (proto.Message, error) {
+		return srv.ListSessions(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_RevokeSession_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.RevokeSessionRequest) (proto.Message, error) {
+		return srv.RevokeSession(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_DeleteAccount_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.DeleteAccountRequest) (proto.Message, error) {
+		return srv.DeleteAccount(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_ExportMyData_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ExportMyDataRequest) (proto.Message, error) {
+		return srv.ExportMyData(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_CompleteTwoFactorLogin_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.CompleteTwoFactorLoginRequest) (proto.Message, error) {
+		return srv.CompleteTwoFactorLogin(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_GetTwoFactorStatus_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.GetTwoFactorStatusRequest) (proto.Message, error) {
+		return srv.GetTwoFactorStatus(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_BeginTOTPEnrollment_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.BeginTOTPEnrollmentRequest) (proto.Message, error) {
+		return srv.BeginTOTPEnrollment(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_ConfirmTOTPEnrollment_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.ConfirmTOTPEnrollmentRequest) (proto.Message, error) {
+		return srv.ConfirmTOTPEnrollment(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_RegenerateRecoveryCodes_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (proto.Message, error) {
+		return srv.RegenerateRecoveryCodes(ctx, req)
+	}))
+	mux.Handle(pb.AuthService_DisableTwoFactor_FullMethodName, unary(proxies, func(ctx context.Context, req *pb.DisableTwoFactorRequest) (proto.Message, error) {
+		return srv.DisableTwoFactor(ctx, req)
+	}))
+
+	return mux
+}
+
+func unary[Req any, PReq interface {
+	*Req
+	proto.Message
+}](proxies TrustedProxies, call func(context.Context, PReq) (proto.Message, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req := PReq(new(Req))
+		if len(body) > 0 {
+			if err := jsonUnmarshal.Unmarshal(body, req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		resp, err := call(withClientInfo(r.Context(), r, proxies), req)
+		if err != nil {
+			st := status.Convert(err)
+			if d := retryAfter(st); d > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+			}
+			http.Error(w, st.Message(), httpStatus(st.Code()))
+			return
+		}
+
+		data, err := jsonMarshal.Marshal(resp)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+func retryAfter(st *status.Status) time.Duration {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration()
+		}
+	}
+	return 0
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+)
+
+// NewJWKSHandler serves the public verification keys at
+// /.well-known/jwks.json. keys is nil when tokens are signed with a shared
+// HS256 secret, in which case the set is empty.
+func NewJWKSHandler(keys *jwtauth.StaticKeySet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		set := &jwtauth.JWKSet{Keys: []jwtauth.JWK{}}
+		if keys != nil {
+			var err error
+			if set, err = keys.JWKS(); err != nil {
+				log.Printf("failed to build JWKS: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(set)
+	})
+}
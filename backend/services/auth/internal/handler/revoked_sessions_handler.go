@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/pawfiler/backend/services/auth/internal/service"
+)
+
+// NewRevokedSessionsHandler serves /sessions/revoked, the list other services
+// poll through jwtauth.RevocationList to reject tokens from sessions that
+// were signed out before the tokens expired.
+func NewRevokedSessionsHandler(svc *service.AuthService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, err := svc.RecentlyRevokedSessions(r.Context())
+		if err != nil {
+			log.Printf("failed to list revoked sessions: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if ids == nil {
+			ids = []string{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(map[string][]string{"session_ids": ids})
+	})
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// AccountDeletionRepository removes a user together with what is keyed to
+// them in the auth schema.
+type AccountDeletionRepository interface {
+	// DeleteAccount forgets the user's sessions, revokes their refresh
+	// tokens, deletes their identities and the user, and queues event in the
+	// outbox, all or nothing.
+	DeleteAccount(ctx context.Context, userID string, event *OutboxEvent) error
+}
+
+type PostgresAccountDeletionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAccountDeletionRepository(db *sql.DB) *PostgresAccountDeletionRepository {
+	return &PostgresAccountDeletionRepository{db: db}
+}
+
+func (r *PostgresAccountDeletionRepository) DeleteAccount(ctx context.Context, userID string, event *OutboxEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := forgetUserSessions(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := revokeUserRefreshTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := deleteUserIdentities(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := deleteUser(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrAccountTokenInvalid = errors.New("account token invalid, expired or already used")
+
+const (
+	PurposeVerifyEmail   = "verify_email"
+	PurposeResetPassword = "reset_password"
+	PurposeGuardian      = "guardian"
+	// PurposeTwoFactor tokens are login challenges awaiting a second factor;
+	// they are returned to the client rather than mailed.
+	PurposeTwoFactor = "two_factor"
+)
+
+// AccountToken is a single-use token mailed to the user for email
+// verification or password reset. Only its SHA-256 hash is stored.
+type AccountToken struct {
+	ID        string
+	UserID    string
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type AccountTokenRepository interface {
+	// Create stores token and discards any unused token the user has for the
+	// same purpose, so only the most recently mailed link works.
+	Create(ctx context.Context, token *AccountToken) error
+	// Consume marks the matching unexpired, unused token as used and returns
+	// it. It returns ErrAccountTokenInvalid otherwise.
+	Consume(ctx context.Context, tokenHash, purpose string) (*AccountToken, error)
+	// Lookup returns the matching unexpired, unused token without using it
+	// up, or ErrAccountTokenInvalid.
+	Lookup(ctx context.Context, tokenHash, purpose string) (*AccountToken, error)
+}
+
+type PostgresAccountTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAccountTokenRepository(db *sql.DB) *PostgresAccountTokenRepository {
+	return &PostgresAccountTokenRepository{db: db}
+}
+
+func (r *PostgresAccountTokenRepository) Create(ctx context.Context, token *AccountToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM auth.account_tokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`,
+		token.UserID, token.Purpose)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO auth.account_tokens (user_id, purpose, token_hash, expires_at)
+	          VALUES ($1, $2, $3, $4)
+	          RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, query, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresAccountTokenRepository) Consume(ctx context.Context, tokenHash, purpose string) (*AccountToken, error) {
+	query := `UPDATE auth.account_tokens SET used_at = NOW()
+	          WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+	          RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at`
+
+	var t AccountToken
+	var usedAt time.Time
+	err := r.db.QueryRowContext(ctx, query, tokenHash, purpose).Scan(
+		&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.ExpiresAt, &usedAt, &t.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrAccountTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.UsedAt = &usedAt
+	return &t, nil
+}
+
+func (r *PostgresAccountTokenRepository) Lookup(ctx context.Context, tokenHash, purpose string) (*AccountToken, error) {
+	query := `SELECT id, user_id, purpose, token_hash, expires_at, created_at
+	          FROM auth.account_tokens
+	          WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()`
+
+	var t AccountToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash, purpose).Scan(
+		&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrAccountTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
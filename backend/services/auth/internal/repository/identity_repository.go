@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrIdentityNotFound = errors.New("identity not found")
+	ErrIdentityLinked   = errors.New("identity already linked to a user")
+)
+
+// UserIdentity links an account at an external OIDC provider, identified by
+// the issuer's subject, to a Pawfiler user.
+type UserIdentity struct {
+	ID          string
+	UserID      string
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+type IdentityRepository interface {
+	Create(ctx context.Context, identity *UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	// TouchLogin records a successful login through the identity and stores
+	// the email the provider currently reports.
+	TouchLogin(ctx context.Context, id, email string) error
+	ListByUser(ctx context.Context, userID string) ([]*UserIdentity, error)
+	DeleteAllForUser(ctx context.Context, userID string) error
+}
+
+type PostgresIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresIdentityRepository(db *sql.DB) *PostgresIdentityRepository {
+	return &PostgresIdentityRepository{db: db}
+}
+
+func (r *PostgresIdentityRepository) Create(ctx context.Context, identity *UserIdentity) error {
+	query := `INSERT INTO auth.user_identities (user_id, provider, subject, email)
+	          VALUES ($1, $2, $3, $4)
+	          RETURNING id, created_at, last_login_at`
+
+	err := r.db.QueryRowContext(ctx, query, identity.UserID, identity.Provider, identity.Subject, identity.Email).
+		Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrIdentityLinked
+	}
+	return err
+}
+
+func (r *PostgresIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error) {
+	query := `SELECT id, user_id, provider, subject, email, created_at, last_login_at
+	          FROM auth.user_identities WHERE provider = $1 AND subject = $2`
+
+	var i UserIdentity
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt, &i.LastLoginAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r *PostgresIdentityRepository) TouchLogin(ctx context.Context, id, email string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE auth.user_identities SET email = $2, last_login_at = NOW() WHERE id = $1`,
+		id, email)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
+
+func (r *PostgresIdentityRepository) ListByUser(ctx context.Context, userID string) ([]*UserIdentity, error) {
+	query := `SELECT id, user_id, provider, subject, email, created_at, last_login_at
+	          FROM auth.user_identities WHERE user_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*UserIdentity
+	for rows.Next() {
+		var i UserIdentity
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt, &i.LastLoginAt); err != nil {
+			return nil, err
+		}
+		identities = append(identities, &i)
+	}
+	return identities, rows.Err()
+}
+
+func (r *PostgresIdentityRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	return deleteUserIdentities(ctx, r.db, userID)
+}
+
+func deleteUserIdentities(ctx context.Context, db execer, userID string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM auth.user_identities WHERE user_id = $1`, userID)
+	return err
+}
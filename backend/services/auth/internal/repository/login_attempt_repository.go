@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// LoginAttempts counts consecutive failed logins for a throttle key such as
+// an email address or client IP.
+type LoginAttempts struct {
+	Failures      int
+	LastFailureAt time.Time
+}
+
+type LoginAttemptRepository interface {
+	// Reserve counts an attempt for key unless blocked, given the attempts
+	// recorded so far and the store's current time, returns a wait; then
+	// nothing is counted and the wait is returned. Checking and counting are
+	// atomic. Failures older than window are forgotten and counting restarts
+	// at one.
+	Reserve(ctx context.Context, key string, window time.Duration, blocked func(a *LoginAttempts, now time.Time) time.Duration) (time.Duration, error)
+	// Release takes back one reserved attempt for key.
+	Release(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
+
+// PostgresLoginAttemptRepository shares attempt counts across replicas.
+type PostgresLoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresLoginAttemptRepository(db *sql.DB) *PostgresLoginAttemptRepository {
+	return &PostgresLoginAttemptRepository{db: db}
+}
+
+// Reserve locks key's row so concurrent reservations for it queue up behind
+// each other. Times are the database's, like last_failure_at.
+func (r *PostgresLoginAttemptRepository) Reserve(ctx context.Context, key string, window time.Duration, blocked func(a *LoginAttempts, now time.Time) time.Duration) (time.Duration, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO auth.login_attempts (key, failures, last_failure_at) VALUES ($1, 0, NOW())
+		 ON CONFLICT (key) DO NOTHING`, key); err != nil {
+		return 0, err
+	}
+	var a LoginAttempts
+	var now time.Time
+	if err := tx.QueryRowContext(ctx,
+		`SELECT failures, last_failure_at, NOW()::timestamp FROM auth.login_attempts WHERE key = $1 FOR UPDATE`, key,
+	).Scan(&a.Failures, &a.LastFailureAt, &now); err != nil {
+		return 0, err
+	}
+	if now.Sub(a.LastFailureAt) > window {
+		a.Failures = 0
+	}
+	if wait := blocked(&a, now); wait > 0 {
+		return wait, nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE auth.login_attempts SET failures = $2, last_failure_at = NOW() WHERE key = $1`,
+		key, a.Failures+1); err != nil {
+		return 0, err
+	}
+	return 0, tx.Commit()
+}
+
+func (r *PostgresLoginAttemptRepository) Release(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE auth.login_attempts SET failures = GREATEST(failures - 1, 0) WHERE key = $1`, key)
+	return err
+}
+
+func (r *PostgresLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM auth.login_attempts WHERE key = $1`, key)
+	return err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import "context"
+
+// MemoryAccountDeletionRepository runs the steps one after another; the
+// in-memory stores cannot fail part way.
+type MemoryAccountDeletionRepository struct {
+	users         UserRepository
+	sessions      SessionRepository
+	refreshTokens RefreshTokenRepository
+	identities    IdentityRepository
+	outbox        *MemoryOutboxRepository
+}
+
+func NewMemoryAccountDeletionRepository(users UserRepository, sessions SessionRepository, refreshTokens RefreshTokenRepository, identities IdentityRepository, outbox *MemoryOutboxRepository) *MemoryAccountDeletionRepository {
+	return &MemoryAccountDeletionRepository{
+		users:         users,
+		sessions:      sessions,
+		refreshTokens: refreshTokens,
+		identities:    identities,
+		outbox:        outbox,
+	}
+}
+
+func (r *MemoryAccountDeletionRepository) DeleteAccount(ctx context.Context, userID string, event *OutboxEvent) error {
+	if _, err := r.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.sessions.ForgetUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.refreshTokens.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.identities.DeleteAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.users.Delete(ctx, userID); err != nil {
+		return err
+	}
+	r.outbox.add(event)
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MemoryAccountTokenRepository struct {
+	mu     sync.Mutex
+	byHash map[string]*AccountToken
+}
+
+func NewMemoryAccountTokenRepository() *MemoryAccountTokenRepository {
+	return &MemoryAccountTokenRepository{byHash: make(map[string]*AccountToken)}
+}
+
+func (r *MemoryAccountTokenRepository) Create(ctx context.Context, token *AccountToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, t := range r.byHash {
+		if t.UserID == token.UserID && t.Purpose == token.Purpose && t.UsedAt == nil {
+			delete(r.byHash, hash)
+		}
+	}
+
+	token.ID = uuid.NewString()
+	token.CreatedAt = time.Now()
+	stored := *token
+	r.byHash[token.TokenHash] = &stored
+	return nil
+}
+
+func (r *MemoryAccountTokenRepository) Consume(ctx context.Context, tokenHash, purpose string) (*AccountToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	t, ok := r.byHash[tokenHash]
+	if !ok || t.Purpose != purpose || t.UsedAt != nil || !now.Before(t.ExpiresAt) {
+		return nil, ErrAccountTokenInvalid
+	}
+
+	t.UsedAt = &now
+	consumed := *t
+	return &consumed, nil
+}
+
+func (r *MemoryAccountTokenRepository) Lookup(ctx context.Context, tokenHash, purpose string) (*AccountToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byHash[tokenHash]
+	if !ok || t.Purpose != purpose || t.UsedAt != nil || !time.Now().Before(t.ExpiresAt) {
+		return nil, ErrAccountTokenInvalid
+	}
+	found := *t
+	return &found, nil
+}
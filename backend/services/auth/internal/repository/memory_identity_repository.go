@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MemoryIdentityRepository struct {
+	mu        sync.Mutex
+	byID      map[string]*UserIdentity
+	bySubject map[string]string
+}
+
+func NewMemoryIdentityRepository() *MemoryIdentityRepository {
+	return &MemoryIdentityRepository{
+		byID:      make(map[string]*UserIdentity),
+		bySubject: make(map[string]string),
+	}
+}
+
+func (r *MemoryIdentityRepository) Create(ctx context.Context, identity *UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := identity.Provider + "\x00" + identity.Subject
+	if _, ok := r.bySubject[key]; ok {
+		return ErrIdentityLinked
+	}
+
+	now := time.Now()
+	identity.ID = uuid.NewString()
+	identity.CreatedAt = now
+	identity.LastLoginAt = now
+	stored := *identity
+	r.byID[identity.ID] = &stored
+	r.bySubject[key] = identity.ID
+	return nil
+}
+
+func (r *MemoryIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.bySubject[provider+"\x00"+subject]
+	if !ok {
+		return nil, ErrIdentityNotFound
+	}
+	i := *r.byID[id]
+	return &i, nil
+}
+
+func (r *MemoryIdentityRepository) TouchLogin(ctx context.Context, id, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return ErrIdentityNotFound
+	}
+	stored.Email = email
+	stored.LastLoginAt = time.Now()
+	return nil
+}
+
+func (r *MemoryIdentityRepository) ListByUser(ctx context.Context, userID string) ([]*UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var identities []*UserIdentity
+	for _, stored := range r.byID {
+		if stored.UserID == userID {
+			i := *stored
+			identities = append(identities, &i)
+		}
+	}
+	sort.Slice(identities, func(i, j int) bool { return identities[i].CreatedAt.Before(identities[j].CreatedAt) })
+	return identities, nil
+}
+
+func (r *MemoryIdentityRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, stored := range r.byID {
+		if stored.UserID == userID {
+			delete(r.bySubject, stored.Provider+"\x00"+stored.Subject)
+			delete(r.byID, id)
+		}
+	}
+	return nil
+}
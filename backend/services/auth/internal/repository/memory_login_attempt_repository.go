@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryAttemptsPruneSize = 10000
+
+type MemoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*LoginAttempts
+}
+
+func NewMemoryLoginAttemptRepository() *MemoryLoginAttemptRepository {
+	return &MemoryLoginAttemptRepository{attempts: make(map[string]*LoginAttempts)}
+}
+
+func (r *MemoryLoginAttemptRepository) Reserve(ctx context.Context, key string, window time.Duration, blocked func(a *LoginAttempts, now time.Time) time.Duration) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if len(r.attempts) >= memoryAttemptsPruneSize {
+		r.prune(now, window)
+	}
+
+	a, ok := r.attempts[key]
+	if !ok || now.Sub(a.LastFailureAt) > window {
+		a = &LoginAttempts{}
+	}
+	copied := *a
+	if wait := blocked(&copied, now); wait > 0 {
+		return wait, nil
+	}
+	a.Failures++
+	a.LastFailureAt = now
+	r.attempts[key] = a
+	return 0, nil
+}
+
+func (r *MemoryLoginAttemptRepository) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a, ok := r.attempts[key]; ok && a.Failures > 0 {
+		a.Failures--
+	}
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) prune(now time.Time, window time.Duration) {
+	for key, a := range r.attempts {
+		if now.Sub(a.LastFailureAt) > window {
+			delete(r.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryOIDCStateRepository struct {
+	mu     sync.Mutex
+	byHash map[string]*OIDCState
+}
+
+func NewMemoryOIDCStateRepository() *MemoryOIDCStateRepository {
+	return &MemoryOIDCStateRepository{byHash: make(map[string]*OIDCState)}
+}
+
+func (r *MemoryOIDCStateRepository) Create(ctx context.Context, state *OIDCState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for hash, s := range r.byHash {
+		if now.After(s.ExpiresAt) {
+			delete(r.byHash, hash)
+		}
+	}
+
+	state.CreatedAt = now
+	stored := *state
+	r.byHash[state.StateHash] = &stored
+	return nil
+}
+
+func (r *MemoryOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*OIDCState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.byHash[stateHash]
+	if !ok {
+		return nil, ErrOIDCStateInvalid
+	}
+	delete(r.byHash, stateHash)
+	if !time.Now().Before(s.ExpiresAt) {
+		return nil, ErrOIDCStateInvalid
+	}
+	consumed := *s
+	return &consumed, nil
+}
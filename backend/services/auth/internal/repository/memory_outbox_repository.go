@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryOutboxRepository struct {
+	mu     sync.Mutex
+	nextID int64
+	events []*OutboxEvent
+}
+
+func NewMemoryOutboxRepository() *MemoryOutboxRepository {
+	return &MemoryOutboxRepository{}
+}
+
+func (r *MemoryOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pending []*OutboxEvent
+	for _, e := range r.events {
+		if len(pending) == limit {
+			break
+		}
+		copied := *e
+		pending = append(pending, &copied)
+	}
+	return pending, nil
+}
+
+// MarkPublished drops the event; there is nothing to keep it for in memory.
+func (r *MemoryOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, e := range r.events {
+		if e.ID == id {
+			r.events = append(r.events[:i], r.events[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *MemoryOutboxRepository) add(event *OutboxEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	stored := *event
+	stored.ID = r.nextID
+	stored.CreatedAt = time.Now()
+	r.events = append(r.events, &stored)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+type MemoryRecoveryCodeRepository struct {
+	mu sync.Mutex
+	// unused maps a user ID to the set of their unused code hashes.
+	unused map[string]map[string]bool
+}
+
+func NewMemoryRecoveryCodeRepository() *MemoryRecoveryCodeRepository {
+	return &MemoryRecoveryCodeRepository{unused: make(map[string]map[string]bool)}
+}
+
+func (r *MemoryRecoveryCodeRepository) Replace(ctx context.Context, userID string, hashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	codes := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		codes[h] = true
+	}
+	r.unused[userID] = codes
+	return nil
+}
+
+func (r *MemoryRecoveryCodeRepository) Use(ctx context.Context, userID string, matches func(hash string) bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash := range r.unused[userID] {
+		if matches(hash) {
+			delete(r.unused[userID], hash)
+			return nil
+		}
+	}
+	return ErrRecoveryCodeInvalid
+}
+
+func (r *MemoryRecoveryCodeRepository) CountUnused(ctx context.Context, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.unused[userID]), nil
+}
+
+func (r *MemoryRecoveryCodeRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.unused, userID)
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MemoryRefreshTokenRepository struct {
+	mu     sync.Mutex
+	byID   map[string]*RefreshToken
+	byHash map[string]string
+}
+
+func NewMemoryRefreshTokenRepository() *MemoryRefreshTokenRepository {
+	return &MemoryRefreshTokenRepository{
+		byID:   make(map[string]*RefreshToken),
+		byHash: make(map[string]string),
+	}
+}
+
+func (r *MemoryRefreshTokenRepository) Create(ctx context.Context, token *RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.insert(token)
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byHash[tokenHash]
+	if !ok {
+		return nil, ErrRefreshTokenNotFound
+	}
+	t := *r.byID[id]
+	return &t, nil
+}
+
+func (r *MemoryRefreshTokenRepository) Rotate(ctx context.Context, oldID string, next *RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.byID[oldID]
+	if !ok {
+		return ErrRefreshTokenNotFound
+	}
+	if old.RevokedAt != nil {
+		return ErrRefreshTokenUsed
+	}
+
+	r.insert(next)
+	now := time.Now()
+	old.RevokedAt = &now
+	old.ReplacedBy = next.ID
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range r.byID {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range r.byID {
+		if t.UserID == userID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) insert(token *RefreshToken) {
+	token.ID = uuid.NewString()
+	token.CreatedAt = time.Now()
+
+	stored := *token
+	r.byID[token.ID] = &stored
+	r.byHash[token.TokenHash] = token.ID
+}
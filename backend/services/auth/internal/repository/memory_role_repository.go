@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// builtinRoles mirrors the roles and permissions seeded by
+// scripts/init-db.sql.
+var builtinRoles = map[string][]string{
+	"admin":          {"auth.roles.manage", "community.posts.moderate", "quiz.questions.write"},
+	"moderator":      {"community.posts.moderate"},
+	"content_author": {"quiz.questions.write"},
+}
+
+type MemoryRoleRepository struct {
+	mu    sync.Mutex
+	users UserRepository
+	roles map[string][]string
+}
+
+// NewMemoryRoleRepository checks users exist through users, as the foreign
+// key does in Postgres.
+func NewMemoryRoleRepository(users UserRepository) *MemoryRoleRepository {
+	return &MemoryRoleRepository{users: users, roles: make(map[string][]string)}
+}
+
+func (r *MemoryRoleRepository) GetGrants(ctx context.Context, userID string) (*Grants, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	grants := &Grants{Roles: slices.Clone(r.roles[userID])}
+	for _, role := range grants.Roles {
+		for _, p := range builtinRoles[role] {
+			if !slices.Contains(grants.Permissions, p) {
+				grants.Permissions = append(grants.Permissions, p)
+			}
+		}
+	}
+	sort.Strings(grants.Permissions)
+	return grants, nil
+}
+
+func (r *MemoryRoleRepository) Assign(ctx context.Context, userID, role string) error {
+	if _, ok := builtinRoles[role]; !ok {
+		return ErrUnknownRole
+	}
+	if _, err := r.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.roles[userID], role) {
+		r.roles[userID] = append(r.roles[userID], role)
+		sort.Strings(r.roles[userID])
+	}
+	return nil
+}
+
+func (r *MemoryRoleRepository) Revoke(ctx context.Context, userID, role string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles[userID] = slices.DeleteFunc(r.roles[userID], func(existing string) bool { return existing == role })
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type MemorySessionRepository struct {
+	mu   sync.Mutex
+	byID map[string]*Session
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{byID: make(map[string]*Session)}
+}
+
+func (r *MemorySessionRepository) Create(ctx context.Context, session *Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	session.CreatedAt = now
+	session.LastSeenAt = now
+	stored := *session
+	r.byID[session.ID] = &stored
+	return nil
+}
+
+func (r *MemorySessionRepository) Get(ctx context.Context, id string) (*Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	s := *stored
+	return &s, nil
+}
+
+func (r *MemorySessionRepository) Touch(ctx context.Context, id, ip string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.byID[id]; ok {
+		stored.LastSeenAt = time.Now()
+		if ip != "" {
+			stored.IPAddress = ip
+		}
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) ListActive(ctx context.Context, userID string, seenSince time.Time) ([]*Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sessions []*Session
+	for _, stored := range r.byID {
+		if stored.UserID == userID && stored.RevokedAt == nil && stored.LastSeenAt.After(seenSince) {
+			s := *stored
+			sessions = append(sessions, &s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (r *MemorySessionRepository) Revoke(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.byID[id]; ok && stored.RevokedAt == nil {
+		now := time.Now()
+		stored.RevokedAt = &now
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, stored := range r.byID {
+		if stored.UserID == userID && stored.RevokedAt == nil {
+			stored.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sessions []*Session
+	for _, stored := range r.byID {
+		if stored.UserID == userID {
+			s := *stored
+			sessions = append(sessions, &s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+func (r *MemorySessionRepository) ForgetUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, stored := range r.byID {
+		if stored.UserID != userID {
+			continue
+		}
+		if stored.RevokedAt == nil {
+			stored.RevokedAt = &now
+		}
+		stored.UserID = ""
+		stored.UserAgent = ""
+		stored.Device = ""
+		stored.IPAddress = ""
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) ListRevokedSince(ctx context.Context, since time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	for id, stored := range r.byID {
+		if stored.RevokedAt != nil && stored.RevokedAt.After(since) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryTOTPRepository struct {
+	mu       sync.Mutex
+	byUserID map[string]*TOTPCredential
+}
+
+func NewMemoryTOTPRepository() *MemoryTOTPRepository {
+	return &MemoryTOTPRepository{byUserID: make(map[string]*TOTPCredential)}
+}
+
+func (r *MemoryTOTPRepository) Get(ctx context.Context, userID string) (*TOTPCredential, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byUserID[userID]
+	if !ok {
+		return nil, ErrTOTPNotFound
+	}
+	c := *stored
+	return &c, nil
+}
+
+func (r *MemoryTOTPRepository) SavePending(ctx context.Context, userID, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.byUserID[userID]; ok && stored.ConfirmedAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+	r.byUserID[userID] = &TOTPCredential{UserID: userID, Secret: secret, CreatedAt: time.Now()}
+	return nil
+}
+
+func (r *MemoryTOTPRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byUserID[userID]
+	if !ok || stored.LastUsedStep >= step {
+		return ErrTOTPStepUsed
+	}
+	stored.LastUsedStep = step
+	if stored.ConfirmedAt == nil {
+		now := time.Now()
+		stored.ConfirmedAt = &now
+	}
+	return nil
+}
+
+func (r *MemoryTOTPRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.byUserID, userID)
+	return nil
+}
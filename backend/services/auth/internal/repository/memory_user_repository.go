@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryUserRepository keeps users in process memory. It is used when no
+// DATABASE_URL is configured and in tests that should not need Postgres.
+type MemoryUserRepository struct {
+	mu      sync.RWMutex
+	byID    map[string]*User
+	byEmail map[string]string
+	// coinsVersion is the version of each user's copied coin balance.
+	coinsVersion map[string]int64
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		byID:         make(map[string]*User),
+		byEmail:      make(map[string]string),
+		coinsVersion: make(map[string]int64),
+	}
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byEmail[user.Email]; ok {
+		return ErrEmailTaken
+	}
+	if r.nicknameTaken(user.Nickname, "") {
+		return ErrNicknameTaken
+	}
+
+	now := time.Now()
+	user.ID = uuid.NewString()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	stored := *user
+	stored.Restrictions = slices.Clone(user.Restrictions)
+	r.byID[user.ID] = &stored
+	r.byEmail[user.Email] = user.ID
+	return nil
+}
+
+func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	u := *r.byID[id]
+	return &u, nil
+}
+
+func (r *MemoryUserRepository) GetByID(ctx context.Context, id string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	u := *stored
+	return &u, nil
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[user.ID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	if r.nicknameTaken(user.Nickname, user.ID) {
+		return ErrNicknameTaken
+	}
+
+	user.UpdatedAt = time.Now()
+	stored.Nickname = user.Nickname
+	stored.AvatarEmoji = user.AvatarEmoji
+	stored.UpdatedAt = user.UpdatedAt
+	return nil
+}
+
+func (r *MemoryUserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	stored.PasswordHash = passwordHash
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *MemoryUserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	now := time.Now()
+	if stored.EmailVerifiedAt == nil {
+		stored.EmailVerifiedAt = &now
+	}
+	stored.UpdatedAt = now
+	return nil
+}
+
+func (r *MemoryUserRepository) UpdateGuardianSettings(ctx context.Context, id string, restrictions []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	now := time.Now()
+	if stored.GuardianConfirmedAt == nil {
+		stored.GuardianConfirmedAt = &now
+	}
+	stored.Restrictions = slices.Clone(restrictions)
+	stored.UpdatedAt = now
+	return nil
+}
+
+func (r *MemoryUserRepository) SetCoins(ctx context.Context, id string, coins int32, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok || r.coinsVersion[id] >= version {
+		return nil
+	}
+	stored.Coins = coins
+	stored.UpdatedAt = time.Now()
+	r.coinsVersion[id] = version
+	return nil
+}
+
+func (r *MemoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.byID[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	delete(r.byEmail, stored.Email)
+	delete(r.byID, id)
+	delete(r.coinsVersion, id)
+	return nil
+}
+
+func (r *MemoryUserRepository) nicknameTaken(nickname, exceptID string) bool {
+	for id, u := range r.byID {
+		if id != exceptID && strings.EqualFold(u.Nickname, nickname) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrOIDCStateInvalid = errors.New("oidc state invalid, expired or already used")
+
+// OIDCState is the server side of a pending authorization-code login: the
+// PKCE verifier and nonce sent with the authorization request, looked up by
+// the hash of the state parameter when the provider redirects back.
+type OIDCState struct {
+	StateHash    string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state *OIDCState) error
+	// Consume deletes and returns the unexpired state with stateHash, so each
+	// authorization response can be redeemed once. It returns
+	// ErrOIDCStateInvalid otherwise.
+	Consume(ctx context.Context, stateHash string) (*OIDCState, error)
+}
+
+type PostgresOIDCStateRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOIDCStateRepository(db *sql.DB) *PostgresOIDCStateRepository {
+	return &PostgresOIDCStateRepository{db: db}
+}
+
+func (r *PostgresOIDCStateRepository) Create(ctx context.Context, state *OIDCState) error {
+	// Abandoned logins are never consumed; clear them out as new ones start.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM auth.oidc_states WHERE expires_at < NOW()`); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO auth.oidc_states (state_hash, provider, code_verifier, nonce, expires_at)
+	          VALUES ($1, $2, $3, $4, $5)
+	          RETURNING created_at`
+	return r.db.QueryRowContext(ctx, query, state.StateHash, state.Provider, state.CodeVerifier, state.Nonce, state.ExpiresAt).
+		Scan(&state.CreatedAt)
+}
+
+func (r *PostgresOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*OIDCState, error) {
+	query := `DELETE FROM auth.oidc_states
+	          WHERE state_hash = $1 AND expires_at > NOW()
+	          RETURNING state_hash, provider, code_verifier, nonce, expires_at, created_at`
+
+	var s OIDCState
+	err := r.db.QueryRowContext(ctx, query, stateHash).Scan(
+		&s.StateHash, &s.Provider, &s.CodeVerifier, &s.Nonce, &s.ExpiresAt, &s.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrOIDCStateInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
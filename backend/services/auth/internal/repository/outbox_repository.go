@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the
+// change it reports and published afterwards, so the change cannot happen
+// without the event eventually going out.
+type OutboxEvent struct {
+	ID        int64
+	Type      string
+	Payload   map[string]interface{}
+	CreatedAt time.Time
+}
+
+type OutboxRepository interface {
+	// Pending returns up to limit unpublished events, oldest first.
+	Pending(ctx context.Context, limit int) ([]*OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+}
+
+type PostgresOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepository(db *sql.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{db: db}
+}
+
+func (r *PostgresOutboxRepository) Pending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, event_type, payload, created_at FROM auth.event_outbox
+		 WHERE published_at IS NULL ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Type, &payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &e.Payload); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE auth.event_outbox SET published_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+func insertOutboxEvent(ctx context.Context, db execer, event *OutboxEvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO auth.event_outbox (event_type, payload) VALUES ($1, $2)`, event.Type, payload)
+	return err
+}
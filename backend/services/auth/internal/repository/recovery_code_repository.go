@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var ErrRecoveryCodeInvalid = errors.New("recovery code invalid or already used")
+
+// RecoveryCodeRepository stores the bcrypt hashes of a user's one-time
+// two-factor recovery codes.
+type RecoveryCodeRepository interface {
+	// Replace discards the user's codes and stores hashes as the new set.
+	Replace(ctx context.Context, userID string, hashes []string) error
+	// Use marks the first unused code whose hash matches accepts as used,
+	// or returns ErrRecoveryCodeInvalid.
+	Use(ctx context.Context, userID string, matches func(hash string) bool) error
+	CountUnused(ctx context.Context, userID string) (int, error)
+	DeleteAllForUser(ctx context.Context, userID string) error
+}
+
+type PostgresRecoveryCodeRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRecoveryCodeRepository(db *sql.DB) *PostgresRecoveryCodeRepository {
+	return &PostgresRecoveryCodeRepository{db: db}
+}
+
+func (r *PostgresRecoveryCodeRepository) Replace(ctx context.Context, userID string, hashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM auth.recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO auth.recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`,
+		userID, pq.StringArray(hashes))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRecoveryCodeRepository) Use(ctx context.Context, userID string, matches func(hash string) bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the unused codes keeps two requests from spending the same
+	// one.
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, code_hash FROM auth.recovery_codes WHERE user_id = $1 AND used_at IS NULL FOR UPDATE`,
+		userID)
+	if err != nil {
+		return err
+	}
+	var id string
+	for rows.Next() {
+		var codeID, hash string
+		if err := rows.Scan(&codeID, &hash); err != nil {
+			rows.Close()
+			return err
+		}
+		if matches(hash) {
+			id = codeID
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if id == "" {
+		return ErrRecoveryCodeInvalid
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE auth.recovery_codes SET used_at = NOW() WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRecoveryCodeRepository) CountUnused(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM auth.recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userID,
+	).Scan(&n)
+	return n, err
+}
+
+func (r *PostgresRecoveryCodeRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM auth.recovery_codes WHERE user_id = $1`, userID)
+	return err
+}
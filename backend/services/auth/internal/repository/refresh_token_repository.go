@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenUsed     = errors.New("refresh token already used or revoked")
+)
+
+// RefreshToken is the server-side record of an opaque refresh token. Only the
+// SHA-256 hash of the token is stored. Tokens that replace one another share a
+// FamilyID, which identifies a single login session.
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy string
+	CreatedAt  time.Time
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Rotate revokes oldID and stores next in one step. It returns
+	// ErrRefreshTokenUsed if oldID was already revoked.
+	Rotate(ctx context.Context, oldID string, next *RefreshToken) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+type PostgresRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRefreshTokenRepository(db *sql.DB) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{db: db}
+}
+
+func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *RefreshToken) error {
+	return insertRefreshToken(ctx, r.db, token)
+}
+
+func (r *PostgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, COALESCE(replaced_by::text, ''), created_at
+	          FROM auth.refresh_tokens WHERE token_hash = $1`
+
+	var t RefreshToken
+	var revokedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &revokedAt, &t.ReplacedBy, &t.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return &t, nil
+}
+
+func (r *PostgresRefreshTokenRepository) Rotate(ctx context.Context, oldID string, next *RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE auth.refresh_tokens SET revoked_at = NOW(), replaced_by = $2
+		 WHERE id = $1 AND revoked_at IS NULL`, oldID, next.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrRefreshTokenUsed
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE auth.refresh_tokens SET revoked_at = NOW()
+	          WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, familyID)
+	return err
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	return revokeUserRefreshTokens(ctx, r.db, userID)
+}
+
+func revokeUserRefreshTokens(ctx context.Context, db execer, userID string) error {
+	query := `UPDATE auth.refresh_tokens SET revoked_at = NOW()
+	          WHERE user_id = $1 AND revoked_at IS NULL`
+	_, err := db.ExecContext(ctx, query, userID)
+	return err
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertRefreshToken(ctx context.Context, q queryRower, token *RefreshToken) error {
+	query := `INSERT INTO auth.refresh_tokens (user_id, family_id, token_hash, expires_at)
+	          VALUES ($1, $2, $3, $4)
+	          RETURNING id, created_at`
+	return q.QueryRowContext(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+}
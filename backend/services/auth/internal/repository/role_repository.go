@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// Grants are a user's roles and the union of their permissions.
+type Grants struct {
+	Roles       []string
+	Permissions []string
+}
+
+type RoleRepository interface {
+	GetGrants(ctx context.Context, userID string) (*Grants, error)
+	// Assign gives userID role; assigning a role the user already has is a
+	// no-op. It returns ErrUnknownRole or ErrUserNotFound for missing rows.
+	Assign(ctx context.Context, userID, role string) error
+	Revoke(ctx context.Context, userID, role string) error
+}
+
+type PostgresRoleRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRoleRepository(db *sql.DB) *PostgresRoleRepository {
+	return &PostgresRoleRepository{db: db}
+}
+
+func (r *PostgresRoleRepository) GetGrants(ctx context.Context, userID string) (*Grants, error) {
+	query := `SELECT
+	              COALESCE(ARRAY_AGG(DISTINCT ur.role) FILTER (WHERE ur.role IS NOT NULL), '{}'),
+	              COALESCE(ARRAY_AGG(DISTINCT rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+	          FROM auth.user_roles ur
+	          LEFT JOIN auth.role_permissions rp ON rp.role = ur.role
+	          WHERE ur.user_id = $1`
+
+	var roles, permissions pq.StringArray
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&roles, &permissions); err != nil {
+		return nil, err
+	}
+	return &Grants{Roles: roles, Permissions: permissions}, nil
+}
+
+func (r *PostgresRoleRepository) Assign(ctx context.Context, userID, role string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO auth.user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		userID, role)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		if pqErr.Constraint == "user_roles_role_fkey" {
+			return ErrUnknownRole
+		}
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func (r *PostgresRoleRepository) Revoke(ctx context.Context, userID, role string) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM auth.user_roles WHERE user_id = $1 AND role = $2`,
+		userID, role)
+	return err
+}
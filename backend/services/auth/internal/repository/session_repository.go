@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is one signed-in device. Its ID is the refresh token family ID,
+// which access tokens carry as the sid claim. UserID is empty once the user
+// has deleted their account.
+type Session struct {
+	ID         string
+	UserID     string
+	UserAgent  string
+	Device     string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	Get(ctx context.Context, id string) (*Session, error)
+	// Touch records activity on the session from ip.
+	Touch(ctx context.Context, id, ip string) error
+	// ListActive returns the user's unrevoked sessions seen since seenSince,
+	// most recently used first.
+	ListActive(ctx context.Context, userID string, seenSince time.Time) ([]*Session, error)
+	Revoke(ctx context.Context, id string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+	// ListByUser returns all of the user's sessions, revoked ones included,
+	// newest first.
+	ListByUser(ctx context.Context, userID string) ([]*Session, error)
+	// ForgetUser revokes the user's sessions and clears their user, device
+	// and IP details. The rows stay so the revocations are still published.
+	ForgetUser(ctx context.Context, userID string) error
+	// ListRevokedSince returns the IDs of sessions revoked after since.
+	ListRevokedSince(ctx context.Context, since time.Time) ([]string, error)
+}
+
+type PostgresSessionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSessionRepository(db *sql.DB) *PostgresSessionRepository {
+	return &PostgresSessionRepository{db: db}
+}
+
+const sessionColumns = `id, user_id, user_agent, device, ip_address, created_at, last_seen_at, revoked_at`
+
+func (r *PostgresSessionRepository) Create(ctx context.Context, session *Session) error {
+	query := `INSERT INTO auth.sessions (id, user_id, user_agent, device, ip_address)
+	          VALUES ($1, $2, $3, $4, $5)
+	          RETURNING created_at, last_seen_at`
+	return r.db.QueryRowContext(ctx, query,
+		session.ID, session.UserID, session.UserAgent, session.Device, session.IPAddress,
+	).Scan(&session.CreatedAt, &session.LastSeenAt)
+}
+
+func (r *PostgresSessionRepository) Get(ctx context.Context, id string) (*Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM auth.sessions WHERE id = $1`
+	s, err := scanSession(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrSessionNotFound
+	}
+	return s, err
+}
+
+func (r *PostgresSessionRepository) Touch(ctx context.Context, id, ip string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE auth.sessions SET last_seen_at = NOW(), ip_address = COALESCE(NULLIF($2, ''), ip_address) WHERE id = $1`,
+		id, ip)
+	return err
+}
+
+func (r *PostgresSessionRepository) ListActive(ctx context.Context, userID string, seenSince time.Time) ([]*Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM auth.sessions
+	          WHERE user_id = $1 AND revoked_at IS NULL AND last_seen_at > $2
+	          ORDER BY last_seen_at DESC`
+
+	return r.list(ctx, query, userID, seenSince)
+}
+
+func (r *PostgresSessionRepository) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM auth.sessions WHERE user_id = $1 ORDER BY created_at DESC`
+	return r.list(ctx, query, userID)
+}
+
+func (r *PostgresSessionRepository) list(ctx context.Context, query string, args ...interface{}) ([]*Session, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *PostgresSessionRepository) Revoke(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE auth.sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	return err
+}
+
+func (r *PostgresSessionRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE auth.sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	return err
+}
+
+func (r *PostgresSessionRepository) ForgetUser(ctx context.Context, userID string) error {
+	return forgetUserSessions(ctx, r.db, userID)
+}
+
+func forgetUserSessions(ctx context.Context, db execer, userID string) error {
+	query := `UPDATE auth.sessions
+	          SET user_id = NULL, user_agent = '', device = '', ip_address = '', revoked_at = COALESCE(revoked_at, NOW())
+	          WHERE user_id = $1`
+	_, err := db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *PostgresSessionRepository) ListRevokedSince(ctx context.Context, since time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM auth.sessions WHERE revoked_at > $1`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*Session, error) {
+	var s Session
+	var userID sql.NullString
+	var revokedAt sql.NullTime
+	err := row.Scan(&s.ID, &userID, &s.UserAgent, &s.Device, &s.IPAddress, &s.CreatedAt, &s.LastSeenAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.UserID = userID.String
+	if revokedAt.Valid {
+		s.RevokedAt = &revokedAt.Time
+	}
+	return &s, nil
+}
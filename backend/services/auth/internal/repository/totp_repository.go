@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTOTPNotFound       = errors.New("totp not set up")
+	ErrTOTPAlreadyEnabled = errors.New("totp already enabled")
+	ErrTOTPStepUsed       = errors.New("totp code already used")
+)
+
+// TOTPCredential is a user's authenticator secret. It only protects logins
+// once ConfirmedAt is set, after the user has proved their app produces
+// matching codes.
+type TOTPCredential struct {
+	UserID       string
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+type TOTPRepository interface {
+	Get(ctx context.Context, userID string) (*TOTPCredential, error)
+	// SavePending stores a new unconfirmed secret, replacing an earlier
+	// unconfirmed one. It returns ErrTOTPAlreadyEnabled if the user has a
+	// confirmed secret.
+	SavePending(ctx context.Context, userID, secret string) error
+	// UseStep records that the code for step was accepted, confirming the
+	// credential if it was pending. It returns ErrTOTPStepUsed unless step is
+	// later than the last accepted one, so each code works only once.
+	UseStep(ctx context.Context, userID string, step int64) error
+	Delete(ctx context.Context, userID string) error
+}
+
+type PostgresTOTPRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTOTPRepository(db *sql.DB) *PostgresTOTPRepository {
+	return &PostgresTOTPRepository{db: db}
+}
+
+func (r *PostgresTOTPRepository) Get(ctx context.Context, userID string) (*TOTPCredential, error) {
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at
+	          FROM auth.totp_credentials WHERE user_id = $1`
+
+	var c TOTPCredential
+	var confirmedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&c.UserID, &c.Secret, &confirmedAt, &c.LastUsedStep, &c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrTOTPNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if confirmedAt.Valid {
+		c.ConfirmedAt = &confirmedAt.Time
+	}
+	return &c, nil
+}
+
+func (r *PostgresTOTPRepository) SavePending(ctx context.Context, userID, secret string) error {
+	query := `INSERT INTO auth.totp_credentials (user_id, secret)
+	          VALUES ($1, $2)
+	          ON CONFLICT (user_id) DO UPDATE SET secret = $2, last_used_step = 0, created_at = NOW()
+	          WHERE auth.totp_credentials.confirmed_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *PostgresTOTPRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	query := `UPDATE auth.totp_credentials
+	          SET last_used_step = $2, confirmed_at = COALESCE(confirmed_at, NOW())
+	          WHERE user_id = $1 AND last_used_step < $2`
+	res, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTOTPStepUsed
+	}
+	return nil
+}
+
+func (r *PostgresTOTPRepository) Delete(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM auth.totp_credentials WHERE user_id = $1`, userID)
+	return err
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrEmailTaken    = errors.New("email already registered")
+	ErrNicknameTaken = errors.New("nickname already taken")
+)
+
+type User struct {
+	ID               string
+	Email            string
+	PasswordHash     string
+	Nickname         string
+	AvatarEmoji      string
+	SubscriptionType string
+	Coins            int32
+	Level            int32
+	LevelTitle       string
+	XP               int32
+	EmailVerifiedAt  *time.Time
+	// BirthYear is 0 when the user did not give one.
+	BirthYear           int32
+	AccountType         string
+	GuardianEmail       string
+	GuardianConfirmedAt *time.Time
+	Restrictions        []string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *User) error
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByID(ctx context.Context, id string) (*User, error)
+	Update(ctx context.Context, user *User) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id string) error
+	// UpdateGuardianSettings stores the restrictions a guardian chose and
+	// records their consent if this is the first time.
+	UpdateGuardianSettings(ctx context.Context, id string, restrictions []string) error
+	// SetCoins copies the coin balance the quiz service owns, unless a copy
+	// of the same or a later version is already stored. A user that no
+	// longer exists is skipped.
+	SetCoins(ctx context.Context, id string, coins int32, version int64) error
+	Delete(ctx context.Context, id string) error
+}
+
+type PostgresUserRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+const userColumns = `id, email, password_hash, nickname, avatar_emoji, subscription_type, coins, level, level_title, xp, email_verified_at,
+	birth_year, account_type, guardian_email, guardian_confirmed_at, restrictions, created_at, updated_at`
+
+func (r *PostgresUserRepository) Create(ctx context.Context, user *User) error {
+	query := `INSERT INTO auth.users (email, password_hash, nickname, avatar_emoji, subscription_type, coins, level, level_title, xp,
+	                                  birth_year, account_type, guardian_email, restrictions)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), $11, NULLIF($12, ''), $13)
+	          RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		user.Email, user.PasswordHash, user.Nickname, user.AvatarEmoji, user.SubscriptionType,
+		user.Coins, user.Level, user.LevelTitle, user.XP,
+		user.BirthYear, user.AccountType, user.GuardianEmail, pq.StringArray(user.Restrictions),
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	return uniqueViolation(err)
+}
+
+func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM auth.users WHERE email = $1`
+	return r.scanUser(r.db.QueryRowContext(ctx, query, email))
+}
+
+func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM auth.users WHERE id = $1`
+	return r.scanUser(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *PostgresUserRepository) Update(ctx context.Context, user *User) error {
+	query := `UPDATE auth.users SET nickname = $2, avatar_emoji = $3, updated_at = NOW()
+	          WHERE id = $1
+	          RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, user.ID, user.Nickname, user.AvatarEmoji).Scan(&user.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrUserNotFound
+	}
+	return uniqueViolation(err)
+}
+
+func (r *PostgresUserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	query := `UPDATE auth.users SET password_hash = $2, updated_at = NOW() WHERE id = $1`
+	return expectOneRow(r.db.ExecContext(ctx, query, id, passwordHash))
+}
+
+func (r *PostgresUserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	query := `UPDATE auth.users SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+	          WHERE id = $1`
+	return expectOneRow(r.db.ExecContext(ctx, query, id))
+}
+
+func (r *PostgresUserRepository) UpdateGuardianSettings(ctx context.Context, id string, restrictions []string) error {
+	query := `UPDATE auth.users
+	          SET restrictions = $2, guardian_confirmed_at = COALESCE(guardian_confirmed_at, NOW()), updated_at = NOW()
+	          WHERE id = $1`
+	return expectOneRow(r.db.ExecContext(ctx, query, id, pq.StringArray(restrictions)))
+}
+
+func (r *PostgresUserRepository) SetCoins(ctx context.Context, id string, coins int32, version int64) error {
+	query := `UPDATE auth.users SET coins = $2, coins_version = $3, updated_at = NOW()
+	          WHERE id = $1 AND coins_version < $3`
+	_, err := r.db.ExecContext(ctx, query, id, coins, version)
+	return err
+}
+
+// Delete removes the user. Refresh tokens, account tokens, identities and
+// role grants go with it through ON DELETE CASCADE.
+func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
+	return deleteUser(ctx, r.db, id)
+}
+
+func deleteUser(ctx context.Context, db execer, id string) error {
+	return expectOneRow(db.ExecContext(ctx, `DELETE FROM auth.users WHERE id = $1`, id))
+}
+
+func expectOneRow(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// uniqueViolation maps unique constraint errors on auth.users to the
+// matching repository error.
+func uniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+		return err
+	}
+	switch pqErr.Constraint {
+	case "idx_users_nickname":
+		return ErrNicknameTaken
+	default:
+		return ErrEmailTaken
+	}
+}
+
+func (r *PostgresUserRepository) scanUser(row *sql.Row) (*User, error) {
+	var u User
+	var verifiedAt, guardianConfirmedAt sql.NullTime
+	var birthYear sql.NullInt32
+	var guardianEmail sql.NullString
+	var restrictions pq.StringArray
+	err := row.Scan(
+		&u.ID, &u.Email, &u.PasswordHash, &u.Nickname, &u.AvatarEmoji, &u.SubscriptionType,
+		&u.Coins, &u.Level, &u.LevelTitle, &u.XP, &verifiedAt,
+		&birthYear, &u.AccountType, &guardianEmail, &guardianConfirmedAt, &restrictions, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if verifiedAt.Valid {
+		u.EmailVerifiedAt = &verifiedAt.Time
+	}
+	if guardianConfirmedAt.Valid {
+		u.GuardianConfirmedAt = &guardianConfirmedAt.Time
+	}
+	u.BirthYear = birthYear.Int32
+	u.GuardianEmail = guardianEmail.String
+	u.Restrictions = restrictions
+	return &u, nil
+}
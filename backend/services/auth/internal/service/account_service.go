@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/mailer"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrInvalidAccountToken  = errors.New("invalid or expired link")
+)
+
+const (
+	verifyEmailTTL   = 24 * time.Hour
+	resetPasswordTTL = time.Hour
+)
+
+// AccountService runs the mailed-link flows: email verification and
+// password reset.
+type AccountService struct {
+	users         repository.UserRepository
+	accountTokens repository.AccountTokenRepository
+	refreshTokens repository.RefreshTokenRepository
+	sessions      repository.SessionRepository
+	mailer        mailer.Mailer
+	appBaseURL    string
+}
+
+func NewAccountService(users repository.UserRepository, accountTokens repository.AccountTokenRepository, refreshTokens repository.RefreshTokenRepository, sessions repository.SessionRepository, m mailer.Mailer, appBaseURL string) *AccountService {
+	return &AccountService{
+		users:         users,
+		accountTokens: accountTokens,
+		refreshTokens: refreshTokens,
+		sessions:      sessions,
+		mailer:        m,
+		appBaseURL:    appBaseURL,
+	}
+}
+
+func (s *AccountService) SendVerificationEmail(ctx context.Context, userID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	raw, err := s.createToken(ctx, user.ID, repository.PurposeVerifyEmail, verifyEmailTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "[Pawfiler] 이메일 주소를 인증해 주세요",
+		Body: fmt.Sprintf("%s 탐정님, 반가워요!\n\n아래 링크를 눌러 이메일 인증을 완료해 주세요. 링크는 24시간 동안 유효해요.\n\n%s/verify-email?token=%s\n",
+			user.Nickname, s.appBaseURL, raw),
+	})
+}
+
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (*repository.User, error) {
+	t, err := s.accountTokens.Consume(ctx, hashToken(token), repository.PurposeVerifyEmail)
+	if errors.Is(err, repository.ErrAccountTokenInvalid) {
+		return nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.users.MarkEmailVerified(ctx, t.UserID); err != nil {
+		return nil, err
+	}
+	return s.users.GetByID(ctx, t.UserID)
+}
+
+// RequestPasswordReset mails a reset link if email belongs to an account. It
+// reports success either way so the endpoint cannot be used to probe for
+// registered addresses.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.users.GetByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := s.createToken(ctx, user.ID, repository.PurposeResetPassword, resetPasswordTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "[Pawfiler] 비밀번호 재설정 안내",
+		Body: fmt.Sprintf("비밀번호 재설정을 요청하셨나요?\n\n아래 링크에서 새 비밀번호를 설정할 수 있어요. 링크는 1시간 동안 유효해요.\n\n%s/reset-password?token=%s\n\n요청하지 않았다면 이 메일을 무시해 주세요.\n",
+			s.appBaseURL, raw),
+	})
+}
+
+// ResetPassword sets a new password and signs the user out everywhere. The
+// reset link proves control of the inbox, so the email is marked verified.
+func (s *AccountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if len(newPassword) < minPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	t, err := s.accountTokens.Consume(ctx, hashToken(token), repository.PurposeResetPassword)
+	if errors.Is(err, repository.ErrAccountTokenInvalid) {
+		return ErrInvalidAccountToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, t.UserID, string(hash)); err != nil {
+		return err
+	}
+	if err := s.users.MarkEmailVerified(ctx, t.UserID); err != nil {
+		return err
+	}
+	if err := s.refreshTokens.RevokeAllForUser(ctx, t.UserID); err != nil {
+		return err
+	}
+	if err := s.sessions.RevokeAllForUser(ctx, t.UserID); err != nil {
+		return err
+	}
+
+	log.Printf("password reset for user %s, all sessions revoked", t.UserID)
+	return nil
+}
+
+func (s *AccountService) createToken(ctx context.Context, userID, purpose string, ttl time.Duration) (string, error) {
+	raw, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.accountTokens.Create(ctx, &repository.AccountToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
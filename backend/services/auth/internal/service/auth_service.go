@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/pawfiler/backend/services/auth/internal/signing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrMissingFields      = errors.New("email, password, and nickname are required")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrPasswordTooShort   = errors.New("password must be at least 6 characters")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrIncorrectPassword  = errors.New("incorrect password")
+)
+
+const minPasswordLength = 6
+
+// dummyHash is compared against when the email is unknown so that login
+// takes the same time whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("pawfiler-dummy-password"), bcrypt.DefaultCost)
+
+// EventEmitter publishes domain events; *kafka.Producer implements it.
+type EventEmitter interface {
+	Emit(eventType string, payload map[string]interface{}) error
+}
+
+type AuthService struct {
+	repo     repository.UserRepository
+	tokens   repository.RefreshTokenRepository
+	signer   signing.Signer
+	verifier *jwtauth.Verifier
+	events   EventEmitter
+	throttle *LoginThrottle
+	roles    repository.RoleRepository
+	sessions repository.SessionRepository
+	// Two-factor login; see two_factor.go.
+	totp          repository.TOTPRepository
+	recoveryCodes repository.RecoveryCodeRepository
+	challenges    repository.AccountTokenRepository
+}
+
+func NewAuthService(repo repository.UserRepository, tokens repository.RefreshTokenRepository, signer signing.Signer, verifier *jwtauth.Verifier, events EventEmitter, throttle *LoginThrottle, roles repository.RoleRepository, sessions repository.SessionRepository, totp repository.TOTPRepository, recoveryCodes repository.RecoveryCodeRepository, challenges repository.AccountTokenRepository) *AuthService {
+	return &AuthService{
+		repo:          repo,
+		tokens:        tokens,
+		signer:        signer,
+		verifier:      verifier,
+		events:        events,
+		throttle:      throttle,
+		roles:         roles,
+		sessions:      sessions,
+		totp:          totp,
+		recoveryCodes: recoveryCodes,
+		challenges:    challenges,
+	}
+}
+
+// Login checks the password for email. client.IP may be empty when unknown;
+// failures are then only counted against the account.
+func (s *AuthService) Login(ctx context.Context, email, password string, client ClientInfo) (*TokenPair, *repository.User, error) {
+	email = normalizeEmail(email)
+	if err := s.throttle.Reserve(ctx, email, client.IP); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.authenticate(ctx, email, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.throttle.Release(ctx, email, client.IP); err != nil {
+		return nil, nil, err
+	}
+	// With two-factor on, the throttle is only reset once the code checks
+	// out, so knowing the password does not buy unlimited code guesses.
+	if err := s.requireSecondFactor(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	if err := s.throttle.Success(ctx, email); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+func (s *AuthService) authenticate(ctx context.Context, email, password string) (*repository.User, error) {
+	user, err := s.repo.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// confirmPassword re-checks a signed-in user's password before a sensitive
+// change. Failures count towards the login throttle. Accounts that only sign
+// in through a social provider have no password to confirm.
+func (s *AuthService) confirmPassword(ctx context.Context, user *repository.User, password string, client ClientInfo) error {
+	if user.PasswordHash == "" {
+		return nil
+	}
+	if err := s.throttle.Reserve(ctx, user.Email, client.IP); err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrIncorrectPassword
+	}
+	return s.throttle.Release(ctx, user.Email, client.IP)
+}
+
+// Signup registers a user. birthYear may be 0 when not given. Child accounts
+// (see isChildSignup) are created inactive and get no tokens until their
+// guardian consents.
+func (s *AuthService) Signup(ctx context.Context, email, password, nickname, avatarEmoji string, birthYear int32, guardianEmail string, client ClientInfo) (*TokenPair, *repository.User, error) {
+	email = normalizeEmail(email)
+	nickname = strings.TrimSpace(nickname)
+	guardianEmail = normalizeEmail(guardianEmail)
+
+	if email == "" || password == "" || nickname == "" {
+		return nil, nil, ErrMissingFields
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, nil, ErrInvalidEmail
+	}
+	if len(password) < minPasswordLength {
+		return nil, nil, ErrPasswordTooShort
+	}
+	if err := validateNickname(nickname); err != nil {
+		return nil, nil, err
+	}
+	if avatarEmoji != "" {
+		if err := validateAvatarEmoji(avatarEmoji); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := validateBirthYear(birthYear); err != nil {
+		return nil, nil, err
+	}
+	child := isChildSignup(birthYear, guardianEmail)
+	if child {
+		if err := validateGuardianEmail(guardianEmail, email); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &repository.User{
+		Email:            email,
+		PasswordHash:     string(hash),
+		Nickname:         nickname,
+		AvatarEmoji:      avatarEmoji,
+		SubscriptionType: "free",
+		Coins:            100,
+		Level:            1,
+		LevelTitle:       "새싹 탐정",
+		XP:               0,
+		BirthYear:        birthYear,
+		AccountType:      jwtauth.AccountTypeStandard,
+	}
+	if child {
+		user.AccountType = jwtauth.AccountTypeChild
+		user.GuardianEmail = guardianEmail
+		user.Restrictions = defaultChildRestrictions()
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	if child {
+		return nil, user, nil
+	}
+
+	tokens, err := s.issueTokens(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*jwtauth.Claims, error) {
+	claims, err := s.verifier.Verify(tokenString)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.SessionID != "" {
+		revoked, err := s.SessionRevoked(ctx, claims.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrInvalidToken
+		}
+	}
+	return claims, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"slices"
+	"time"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/mailer"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var (
+	ErrInvalidBirthYear       = errors.New("invalid birth year")
+	ErrGuardianEmailRequired  = errors.New("guardian email is required for child accounts")
+	ErrInvalidGuardianEmail   = errors.New("invalid guardian email")
+	ErrGuardianConsentPending = errors.New("waiting for guardian consent")
+	ErrUnknownRestriction     = errors.New("unknown restriction")
+)
+
+const (
+	// Korean law requires a guardian's consent to collect personal data from
+	// children under 14.
+	childAgeLimit = 14
+	minBirthYear  = 1900
+
+	guardianConsentTTL = 7 * 24 * time.Hour
+	guardianLinkTTL    = time.Hour
+)
+
+func validateBirthYear(birthYear int32) error {
+	if birthYear == 0 {
+		return nil
+	}
+	if birthYear < minBirthYear || int(birthYear) > time.Now().Year() {
+		return ErrInvalidBirthYear
+	}
+	return nil
+}
+
+// isChildSignup decides whether a signup creates a child account: either the
+// birth year says the user may be under childAgeLimit, or a guardian email
+// was given to opt in. With only the year we cannot tell whether this year's
+// birthday has passed, so anyone who might still be 13 counts.
+func isChildSignup(birthYear int32, guardianEmail string) bool {
+	if guardianEmail != "" {
+		return true
+	}
+	return birthYear != 0 && time.Now().Year()-int(birthYear) <= childAgeLimit
+}
+
+func validateGuardianEmail(guardianEmail, childEmail string) error {
+	if guardianEmail == "" {
+		return ErrGuardianEmailRequired
+	}
+	if _, err := mail.ParseAddress(guardianEmail); err != nil || guardianEmail == childEmail {
+		return ErrInvalidGuardianEmail
+	}
+	return nil
+}
+
+// defaultChildRestrictions applies every restriction until the guardian
+// chooses otherwise.
+func defaultChildRestrictions() []string {
+	return slices.Clone(jwtauth.AllRestrictions)
+}
+
+func awaitingGuardian(user *repository.User) bool {
+	return user.AccountType == jwtauth.AccountTypeChild && user.GuardianConfirmedAt == nil
+}
+
+// SendGuardianConsent mails the guardian of a child account a link to
+// approve it and choose its restrictions.
+func (s *AccountService) SendGuardianConsent(ctx context.Context, userID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.sendGuardianLink(ctx, user)
+}
+
+// RequestGuardianLink mails a fresh guardian link for the child account
+// registered with childEmail: the consent link again while the account is
+// pending, or a link to change restrictions afterwards. Like
+// RequestPasswordReset it reports success for unknown addresses.
+func (s *AccountService) RequestGuardianLink(ctx context.Context, childEmail string) error {
+	user, err := s.users.GetByEmail(ctx, normalizeEmail(childEmail))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.AccountType != jwtauth.AccountTypeChild {
+		return nil
+	}
+	return s.sendGuardianLink(ctx, user)
+}
+
+func (s *AccountService) sendGuardianLink(ctx context.Context, user *repository.User) error {
+	if user.AccountType != jwtauth.AccountTypeChild || user.GuardianEmail == "" {
+		return fmt.Errorf("user %s is not a child account", user.ID)
+	}
+
+	if awaitingGuardian(user) {
+		raw, err := s.createToken(ctx, user.ID, repository.PurposeGuardian, guardianConsentTTL)
+		if err != nil {
+			return err
+		}
+		return s.mailer.Send(ctx, mailer.Message{
+			To:      user.GuardianEmail,
+			Subject: "[Pawfiler] 자녀 계정 보호자 동의 요청",
+			Body: fmt.Sprintf("%s 어린이가 딥페이크 탐정 교육 서비스 Pawfiler에 가입을 신청했어요.\n\n아래 링크에서 가입에 동의하고 커뮤니티 글쓰기, 결제 등 이용 제한을 설정해 주세요. 동의 전에는 로그인할 수 없어요. 링크는 7일 동안 유효해요.\n\n%s/guardian?token=%s\n\n신청한 적이 없다면 이 메일을 무시해 주세요.\n",
+				user.Nickname, s.appBaseURL, raw),
+		})
+	}
+
+	raw, err := s.createToken(ctx, user.ID, repository.PurposeGuardian, guardianLinkTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.GuardianEmail,
+		Subject: "[Pawfiler] 자녀 계정 이용 제한 설정",
+		Body: fmt.Sprintf("%s 어린이 계정의 이용 제한을 바꿀 수 있는 링크예요. 링크는 1시간 동안 유효해요.\n\n%s/guardian?token=%s\n",
+			user.Nickname, s.appBaseURL, raw),
+	})
+}
+
+// GuardianSettings returns the child account a guardian link belongs to,
+// leaving the link usable so the settings page can show the current state.
+func (s *AccountService) GuardianSettings(ctx context.Context, token string) (*repository.User, error) {
+	t, err := s.accountTokens.Lookup(ctx, hashToken(token), repository.PurposeGuardian)
+	if errors.Is(err, repository.ErrAccountTokenInvalid) {
+		return nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.users.GetByID(ctx, t.UserID)
+}
+
+// UpdateGuardianSettings uses up a guardian link to set the child's
+// restrictions, activating the account if the guardian had not consented
+// yet. Access tokens already issued keep their old restrictions until they
+// are refreshed.
+func (s *AccountService) UpdateGuardianSettings(ctx context.Context, token string, restrictions []string) (*repository.User, error) {
+	normalized := make([]string, 0, len(restrictions))
+	for _, r := range restrictions {
+		if !slices.Contains(jwtauth.AllRestrictions, r) {
+			return nil, ErrUnknownRestriction
+		}
+		if !slices.Contains(normalized, r) {
+			normalized = append(normalized, r)
+		}
+	}
+
+	t, err := s.accountTokens.Consume(ctx, hashToken(token), repository.PurposeGuardian)
+	if errors.Is(err, repository.ErrAccountTokenInvalid) {
+		return nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.users.UpdateGuardianSettings(ctx, t.UserID, normalized); err != nil {
+		return nil, err
+	}
+	log.Printf("guardian updated settings for user %s: restrictions %v", t.UserID, normalized)
+	return s.users.GetByID(ctx, t.UserID)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var ErrTooManyAttempts = errors.New("too many login attempts")
+
+// RateLimitError is returned by Login while a key is backing off or locked.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrTooManyAttempts, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// ThrottlePolicy describes how failed logins for one kind of key are slowed
+// down. Window must be at least LockoutDuration.
+type ThrottlePolicy struct {
+	FreeAttempts    int           // failures allowed before any delay
+	BaseDelay       time.Duration // delay after the first failure past FreeAttempts, doubled each time
+	MaxDelay        time.Duration
+	LockoutAfter    int // failures that lock the key for LockoutDuration
+	LockoutDuration time.Duration
+	Window          time.Duration // failures older than this are forgotten
+}
+
+var (
+	DefaultAccountPolicy = ThrottlePolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAfter:    10,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+	DefaultIPPolicy = ThrottlePolicy{
+		FreeAttempts:    20,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAfter:    100,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+)
+
+func (p ThrottlePolicy) blockedFor(a *repository.LoginAttempts, now time.Time) time.Duration {
+	elapsed := now.Sub(a.LastFailureAt)
+	if a.Failures <= p.FreeAttempts || elapsed > p.Window {
+		return 0
+	}
+
+	var delay time.Duration
+	if a.Failures >= p.LockoutAfter {
+		delay = p.LockoutDuration
+	} else {
+		exp := math.Pow(2, float64(a.Failures-p.FreeAttempts-1))
+		delay = time.Duration(math.Min(float64(p.BaseDelay)*exp, float64(p.MaxDelay)))
+	}
+
+	if remaining := delay - elapsed; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// LoginThrottle tracks failed logins per account and per client IP.
+type LoginThrottle struct {
+	store   repository.LoginAttemptRepository
+	account ThrottlePolicy
+	ip      ThrottlePolicy
+}
+
+func NewLoginThrottle(store repository.LoginAttemptRepository, account, ip ThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{store: store, account: account, ip: ip}
+}
+
+// Reserve counts an attempt against the IP and the account before the
+// credential is checked, or returns a *RateLimitError without counting it if
+// either must wait. The store checks and counts in one step, so concurrent
+// attempts cannot all get in under the limit. The IP goes first so that a
+// blocked IP adds nothing to the account. A reserved attempt stays counted
+// as a failure unless Release takes it back.
+func (t *LoginThrottle) Reserve(ctx context.Context, email, ip string) error {
+	for _, k := range t.keys(email, ip) {
+		policy := k.policy
+		wait, err := t.store.Reserve(ctx, k.key, policy.Window, policy.blockedFor)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			return &RateLimitError{RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+// Release takes back an attempt reserved for a credential that checked out.
+func (t *LoginThrottle) Release(ctx context.Context, email, ip string) error {
+	for _, k := range t.keys(email, ip) {
+		if err := t.store.Release(ctx, k.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Success clears the account's failures. The IP counter is left alone so a
+// valid login cannot be used to reset an IP that is guessing other accounts.
+func (t *LoginThrottle) Success(ctx context.Context, email string) error {
+	return t.store.Reset(ctx, "account:"+email)
+}
+
+type throttleKey struct {
+	key    string
+	policy ThrottlePolicy
+}
+
+func (t *LoginThrottle) keys(email, ip string) []throttleKey {
+	var keys []throttleKey
+	if ip != "" {
+		keys = append(keys, throttleKey{key: "ip:" + ip, policy: t.ip})
+	}
+	return append(keys, throttleKey{key: "account:" + email, policy: t.account})
+}
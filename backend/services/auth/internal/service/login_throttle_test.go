@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var testPolicy = ThrottlePolicy{
+	FreeAttempts:    2,
+	BaseDelay:       time.Minute,
+	MaxDelay:        time.Hour,
+	LockoutAfter:    5,
+	LockoutDuration: time.Hour,
+	Window:          time.Hour,
+}
+
+func newTestThrottle() *LoginThrottle {
+	return NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), testPolicy, testPolicy)
+}
+
+func TestLoginThrottleBlocksAfterFreeAttempts(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+
+	for i := 0; i <= testPolicy.FreeAttempts; i++ {
+		if err := throttle.Reserve(ctx, testEmail, "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	err := throttle.Reserve(ctx, testEmail, "10.0.0.1")
+	var limited *RateLimitError
+	if !errors.As(err, &limited) {
+		t.Fatalf("got %v, want *RateLimitError", err)
+	}
+	if !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("%v does not wrap ErrTooManyAttempts", err)
+	}
+	if limited.RetryAfter <= 0 || limited.RetryAfter > testPolicy.BaseDelay {
+		t.Fatalf("RetryAfter = %s, want within (0, %s]", limited.RetryAfter, testPolicy.BaseDelay)
+	}
+}
+
+func TestLoginThrottleReleaseTakesBackAttempt(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+
+	for i := 0; i < 3*testPolicy.LockoutAfter; i++ {
+		if err := throttle.Reserve(ctx, testEmail, "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+		if err := throttle.Release(ctx, testEmail, "10.0.0.1"); err != nil {
+			t.Fatalf("release %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestLoginThrottleSuccessKeepsIPCount(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+
+	for i := 0; i <= testPolicy.FreeAttempts; i++ {
+		if err := throttle.Reserve(ctx, "other@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	if err := throttle.Success(ctx, "other@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.Reserve(ctx, testEmail, "10.0.0.1"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("same IP after Success: got %v, want %v", err, ErrTooManyAttempts)
+	}
+	if err := throttle.Reserve(ctx, testEmail, "10.0.0.2"); err != nil {
+		t.Fatalf("other IP: %v", err)
+	}
+}
+
+func TestLoginThrottleBlockedIPDoesNotCountForAccount(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+
+	for i := 0; i <= testPolicy.FreeAttempts; i++ {
+		if err := throttle.Reserve(ctx, "other@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	for i := 0; i < testPolicy.LockoutAfter; i++ {
+		if err := throttle.Reserve(ctx, testEmail, "10.0.0.1"); !errors.Is(err, ErrTooManyAttempts) {
+			t.Fatalf("attempt %d from blocked IP: got %v, want %v", i+1, err, ErrTooManyAttempts)
+		}
+	}
+	if err := throttle.Reserve(ctx, testEmail, "10.0.0.2"); err != nil {
+		t.Fatalf("account was charged for attempts from a blocked IP: %v", err)
+	}
+}
+
+func TestThrottlePolicyBlockedFor(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		failures int
+		ago      time.Duration
+		want     time.Duration
+	}{
+		{"free attempts", 2, 0, 0},
+		{"first delay", 3, 0, time.Minute},
+		{"doubled delay", 4, 0, 2 * time.Minute},
+		{"delay partly served", 4, 30 * time.Second, 90 * time.Second},
+		{"delay served", 3, 2 * time.Minute, 0},
+		{"locked out", 5, 0, time.Hour},
+		{"outside window", 5, 2 * time.Hour, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &repository.LoginAttempts{Failures: tt.failures, LastFailureAt: now.Add(-tt.ago)}
+			if got := testPolicy.blockedFor(a, now); got != tt.want {
+				t.Fatalf("blockedFor = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/oidc"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var (
+	ErrUnknownProvider   = errors.New("unknown login provider")
+	ErrInvalidOIDCState  = errors.New("login session invalid or expired")
+	ErrOIDCLoginFailed   = errors.New("social login failed")
+	ErrOIDCEmailRequired = errors.New("provider did not share an email address")
+	ErrOIDCAccountExists = errors.New("an account with this email already exists")
+)
+
+const (
+	oidcStateTTL         = 10 * time.Minute
+	defaultOIDCNickname  = "탐정"
+	nicknameSuffixDigits = 4
+	maxNicknameAttempts  = 5
+)
+
+// OIDCService signs users in through external OpenID Connect providers. The
+// first login with an identity links it to the account with the same
+// provider-verified email, or creates a new passwordless account.
+type OIDCService struct {
+	providers  *oidc.Registry
+	states     repository.OIDCStateRepository
+	identities repository.IdentityRepository
+	users      repository.UserRepository
+	auth       *AuthService
+}
+
+func NewOIDCService(providers *oidc.Registry, states repository.OIDCStateRepository, identities repository.IdentityRepository, users repository.UserRepository, auth *AuthService) *OIDCService {
+	return &OIDCService{
+		providers:  providers,
+		states:     states,
+		identities: identities,
+		users:      users,
+		auth:       auth,
+	}
+}
+
+func (s *OIDCService) Providers() []string {
+	return s.providers.Names()
+}
+
+// StartLogin returns the provider URL to send the browser to and the state
+// value it will come back with. The caller should keep state and check it
+// against the redirect before calling CompleteLogin.
+func (s *OIDCService) StartLogin(ctx context.Context, providerName string) (authURL, state string, err error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return "", "", ErrUnknownProvider
+	}
+
+	state, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oidc.NewCodeVerifier()
+
+	authURL, err = provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.states.Create(ctx, &repository.OIDCState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// CompleteLogin redeems the authorization code the provider redirected back
+// with. created reports whether a new account was made for the identity.
+// Accounts with two-factor login get a *TwoFactorRequiredError instead of
+// tokens, as from Login.
+func (s *OIDCService) CompleteLogin(ctx context.Context, state, code string, client ClientInfo) (tokens *TokenPair, user *repository.User, created bool, err error) {
+	pending, err := s.states.Consume(ctx, hashToken(state))
+	if errors.Is(err, repository.ErrOIDCStateInvalid) {
+		return nil, nil, false, ErrInvalidOIDCState
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	provider, err := s.providers.Get(pending.Provider)
+	if err != nil {
+		return nil, nil, false, ErrUnknownProvider
+	}
+	identity, err := provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		log.Printf("oidc login with %s failed: %v", pending.Provider, err)
+		return nil, nil, false, ErrOIDCLoginFailed
+	}
+
+	linked, err := s.identities.GetByProviderSubject(ctx, pending.Provider, identity.Subject)
+	switch {
+	case err == nil:
+		user, err = s.users.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if err := s.identities.TouchLogin(ctx, linked.ID, identity.Email); err != nil {
+			return nil, nil, false, err
+		}
+	case errors.Is(err, repository.ErrIdentityNotFound):
+		user, created, err = s.linkOrCreateUser(ctx, pending.Provider, identity)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	default:
+		return nil, nil, false, err
+	}
+
+	if err := s.auth.requireSecondFactor(ctx, user); err != nil {
+		return nil, nil, false, err
+	}
+	tokens, err = s.auth.issueTokens(ctx, user, client)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return tokens, user, created, nil
+}
+
+// linkOrCreateUser attaches a first-seen identity to an account. An existing
+// account is only linked when both the provider and Pawfiler have verified
+// the email; otherwise whoever registered the address first could be handed
+// someone else's account, or vice versa.
+func (s *OIDCService) linkOrCreateUser(ctx context.Context, provider string, identity *oidc.Identity) (*repository.User, bool, error) {
+	email := normalizeEmail(identity.Email)
+	if email == "" {
+		return nil, false, ErrOIDCEmailRequired
+	}
+
+	created := false
+	user, err := s.users.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !identity.EmailVerified || user.EmailVerifiedAt == nil {
+			return nil, false, ErrOIDCAccountExists
+		}
+	case errors.Is(err, repository.ErrUserNotFound):
+		user, err = s.createUser(ctx, email, identity)
+		if err != nil {
+			return nil, false, err
+		}
+		created = true
+	default:
+		return nil, false, err
+	}
+
+	err = s.identities.Create(ctx, &repository.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return user, created, nil
+}
+
+// createUser makes a passwordless account; the user can set a password later
+// through the reset flow. The nickname comes from the provider profile and
+// gets a numeric suffix when it is already taken.
+func (s *OIDCService) createUser(ctx context.Context, email string, identity *oidc.Identity) (*repository.User, error) {
+	base := oidcNickname(identity.Name, email)
+	nickname := base
+
+	for attempt := 1; ; attempt++ {
+		user := &repository.User{
+			Email:            email,
+			Nickname:         nickname,
+			SubscriptionType: "free",
+			Coins:            100,
+			Level:            1,
+			LevelTitle:       "새싹 탐정",
+			XP:               0,
+			AccountType:      jwtauth.AccountTypeStandard,
+		}
+		err := s.users.Create(ctx, user)
+		if errors.Is(err, repository.ErrNicknameTaken) && attempt < maxNicknameAttempts {
+			suffix, err := randomDigits(nicknameSuffixDigits)
+			if err != nil {
+				return nil, err
+			}
+			nickname = truncateRunes(base, maxNicknameLength-nicknameSuffixDigits) + suffix
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if identity.EmailVerified {
+			if err := s.users.MarkEmailVerified(ctx, user.ID); err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+		return user, nil
+	}
+}
+
+// oidcNickname picks a valid nickname from the provider's display name,
+// falling back to the email's local part and then a default.
+func oidcNickname(name, email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	for _, candidate := range []string{name, local} {
+		candidate = truncateRunes(strings.TrimSpace(candidate), maxNicknameLength)
+		if validateNickname(candidate) == nil {
+			return candidate
+		}
+	}
+	return defaultOIDCNickname
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func randomDigits(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = '0' + b[i]%10
+	}
+	return string(b), nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+const outboxBatchSize = 100
+
+// OutboxRelay publishes events queued in the outbox, oldest first. An event
+// that fails to publish is tried again on the next pass, and one published
+// just before a crash may go out twice, so consumers must tolerate
+// duplicates.
+type OutboxRelay struct {
+	outbox   repository.OutboxRepository
+	events   EventEmitter
+	interval time.Duration
+	wake     chan struct{}
+}
+
+func NewOutboxRelay(outbox repository.OutboxRepository, events EventEmitter) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:   outbox,
+		events:   events,
+		interval: 5 * time.Second,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Wake asks for a pass now rather than at the next interval.
+func (r *OutboxRelay) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes pending events every interval, and when woken, until ctx is
+// done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.publishPending(ctx); err != nil {
+			log.Printf("failed to publish outbox events: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// publishPending stops at the first failure so events keep their order.
+func (r *OutboxRelay) publishPending(ctx context.Context) error {
+	for {
+		events, err := r.outbox.Pending(ctx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := r.events.Emit(e.Type, e.Payload); err != nil {
+				return err
+			}
+			if err := r.outbox.MarkPublished(ctx, e.ID); err != nil {
+				return err
+			}
+		}
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
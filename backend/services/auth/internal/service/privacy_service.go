@@ -0,0 +1,322 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/export"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var (
+	ErrUnknownExportFormat = errors.New("unknown export format")
+	ErrExportUnavailable   = errors.New("data export temporarily unavailable")
+)
+
+const (
+	ExportFormatZIP  = "zip"
+	ExportFormatJSON = "json"
+)
+
+// PrivacyService deletes accounts and assembles personal data exports.
+// Other services hold their own data about the user: they erase it when they
+// see the user.deleted event and contribute to exports through an
+// export.Source.
+type PrivacyService struct {
+	users      repository.UserRepository
+	identities repository.IdentityRepository
+	sessions   repository.SessionRepository
+	roles      repository.RoleRepository
+	deletions  repository.AccountDeletionRepository
+	relay      *OutboxRelay
+	sources    []export.Source
+	auth       *AuthService
+}
+
+func NewPrivacyService(users repository.UserRepository, identities repository.IdentityRepository, sessions repository.SessionRepository, roles repository.RoleRepository, deletions repository.AccountDeletionRepository, relay *OutboxRelay, sources []export.Source, auth *AuthService) *PrivacyService {
+	return &PrivacyService{
+		users:      users,
+		identities: identities,
+		sessions:   sessions,
+		roles:      roles,
+		deletions:  deletions,
+		relay:      relay,
+		sources:    sources,
+		auth:       auth,
+	}
+}
+
+// DeleteAccount removes the user after confirming their password. Their
+// sessions are revoked so outstanding access tokens stop working, and are
+// kept without user or device details so the revocations are still
+// published. The user.deleted event is queued in the outbox in the same
+// transaction, so other services hear of every deletion even if publishing
+// has to be retried.
+func (s *PrivacyService) DeleteAccount(ctx context.Context, userID, password string, client ClientInfo) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.auth.confirmPassword(ctx, user, password, client); err != nil {
+		return err
+	}
+
+	if err := s.deletions.DeleteAccount(ctx, user.ID, &repository.OutboxEvent{
+		Type: "user.deleted",
+		Payload: map[string]interface{}{
+			"user_id":    user.ID,
+			"deleted_at": time.Now().UTC().Format(time.RFC3339),
+		},
+	}); err != nil {
+		return err
+	}
+	s.relay.Wake()
+	// Login attempts are keyed by email, which must not outlive the account.
+	if err := s.auth.throttle.Success(ctx, user.Email); err != nil {
+		log.Printf("failed to clear login attempts for deleted user %s: %v", user.ID, err)
+	}
+
+	log.Printf("user %s deleted their account", user.ID)
+	return nil
+}
+
+// DataExport is a finished export ready to hand to the user.
+type DataExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// ExportData collects everything stored about the user, here and at every
+// export source, into a ZIP archive with one JSON file per service or a
+// single JSON document. sessionID is the caller's session; the sources are
+// called with a fresh access token for it. The export fails as a whole if
+// any source does, rather than silently leaving a service out.
+func (s *PrivacyService) ExportData(ctx context.Context, userID, sessionID, format string) (*DataExport, error) {
+	if format == "" {
+		format = ExportFormatZIP
+	}
+	if format != ExportFormatZIP && format != ExportFormatJSON {
+		return nil, ErrUnknownExportFormat
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	authData, err := s.authData(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []exportSection{{name: "auth", data: authData}}
+	if len(s.sources) > 0 {
+		token, err := s.auth.generateToken(ctx, user, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range s.sources {
+			data, err := src.Export(ctx, token)
+			if err != nil {
+				log.Printf("data export for user %s: %s failed: %v", user.ID, src.Name(), err)
+				return nil, fmt.Errorf("%w: %s", ErrExportUnavailable, src.Name())
+			}
+			sections = append(sections, exportSection{name: src.Name(), data: data})
+		}
+	}
+
+	generatedAt := time.Now().UTC()
+	filename := "pawfiler-data-" + generatedAt.Format("20060102") + "." + format
+	if format == ExportFormatJSON {
+		data, err := exportJSON(user.ID, generatedAt, sections)
+		if err != nil {
+			return nil, err
+		}
+		return &DataExport{Filename: filename, ContentType: "application/json", Data: data}, nil
+	}
+	data, err := exportZIP(user.ID, generatedAt, sections)
+	if err != nil {
+		return nil, err
+	}
+	return &DataExport{Filename: filename, ContentType: "application/zip", Data: data}, nil
+}
+
+type exportSection struct {
+	name string
+	data json.RawMessage
+}
+
+type exportedProfile struct {
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	Nickname            string     `json:"nickname"`
+	AvatarEmoji         string     `json:"avatar_emoji"`
+	SubscriptionType    string     `json:"subscription_type"`
+	Coins               int32      `json:"coins"`
+	Level               int32      `json:"level"`
+	LevelTitle          string     `json:"level_title"`
+	XP                  int32      `json:"xp"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty"`
+	BirthYear           int32      `json:"birth_year,omitempty"`
+	AccountType         string     `json:"account_type"`
+	GuardianEmail       string     `json:"guardian_email,omitempty"`
+	GuardianConfirmedAt *time.Time `json:"guardian_confirmed_at,omitempty"`
+	Restrictions        []string   `json:"restrictions,omitempty"`
+	HasPassword         bool       `json:"has_password"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type exportedIdentity struct {
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"subject"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+type exportedSession struct {
+	ID         string     `json:"id"`
+	Device     string     `json:"device"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (s *PrivacyService) authData(ctx context.Context, user *repository.User) (json.RawMessage, error) {
+	identities, err := s.identities.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessions.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.roles.GetGrants(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Profile     exportedProfile    `json:"profile"`
+		Identities  []exportedIdentity `json:"identities"`
+		Sessions    []exportedSession  `json:"sessions"`
+		Roles       []string           `json:"roles"`
+		Permissions []string           `json:"permissions"`
+	}{
+		Profile: exportedProfile{
+			ID:                  user.ID,
+			Email:               user.Email,
+			Nickname:            user.Nickname,
+			AvatarEmoji:         user.AvatarEmoji,
+			SubscriptionType:    user.SubscriptionType,
+			Coins:               user.Coins,
+			Level:               user.Level,
+			LevelTitle:          user.LevelTitle,
+			XP:                  user.XP,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			BirthYear:           user.BirthYear,
+			AccountType:         user.AccountType,
+			GuardianEmail:       user.GuardianEmail,
+			GuardianConfirmedAt: user.GuardianConfirmedAt,
+			Restrictions:        user.Restrictions,
+			HasPassword:         user.PasswordHash != "",
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+		},
+		Identities:  make([]exportedIdentity, 0, len(identities)),
+		Sessions:    make([]exportedSession, 0, len(sessions)),
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+	}
+	for _, i := range identities {
+		data.Identities = append(data.Identities, exportedIdentity{
+			Provider:    i.Provider,
+			Subject:     i.Subject,
+			Email:       i.Email,
+			CreatedAt:   i.CreatedAt,
+			LastLoginAt: i.LastLoginAt,
+		})
+	}
+	for _, sess := range sessions {
+		data.Sessions = append(data.Sessions, exportedSession{
+			ID:         sess.ID,
+			Device:     sess.Device,
+			UserAgent:  sess.UserAgent,
+			IPAddress:  sess.IPAddress,
+			CreatedAt:  sess.CreatedAt,
+			LastSeenAt: sess.LastSeenAt,
+			RevokedAt:  sess.RevokedAt,
+		})
+	}
+	return json.Marshal(data)
+}
+
+type exportManifest struct {
+	UserID      string    `json:"user_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Services    []string  `json:"services"`
+}
+
+func newExportManifest(userID string, generatedAt time.Time, sections []exportSection) exportManifest {
+	m := exportManifest{UserID: userID, GeneratedAt: generatedAt}
+	for _, sec := range sections {
+		m.Services = append(m.Services, sec.name)
+	}
+	return m
+}
+
+// exportJSON puts the manifest and every service's data in one document.
+func exportJSON(userID string, generatedAt time.Time, sections []exportSection) ([]byte, error) {
+	data := make(map[string]json.RawMessage, len(sections))
+	for _, sec := range sections {
+		data[sec.name] = sec.data
+	}
+	return json.MarshalIndent(struct {
+		exportManifest
+		Data map[string]json.RawMessage `json:"data"`
+	}{newExportManifest(userID, generatedAt, sections), data}, "", "  ")
+}
+
+func exportZIP(userID string, generatedAt time.Time, sections []exportSection) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	manifest, err := json.MarshalIndent(newExportManifest(userID, generatedAt, sections), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files := []exportSection{{name: "manifest", data: manifest}}
+	files = append(files, sections...)
+
+	for _, f := range files {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, f.data, "", "  "); err != nil {
+			return nil, err
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name + ".json",
+			Method:   zip.Deflate,
+			Modified: generatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(pretty.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
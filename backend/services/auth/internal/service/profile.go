@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/rivo/uniseg"
+)
+
+var (
+	ErrInvalidNickname    = errors.New("nickname must be 2 to 20 characters")
+	ErrInvalidAvatarEmoji = errors.New("avatar must be a single emoji")
+)
+
+const (
+	minNicknameLength = 2
+	maxNicknameLength = 20
+	// auth.users.avatar_emoji is VARCHAR(10); ZWJ sequences such as family
+	// emoji use several code points for one grapheme.
+	maxAvatarCodePoints = 10
+)
+
+func (s *AuthService) GetProfile(ctx context.Context, userID string) (*repository.User, error) {
+	return s.repo.GetByID(ctx, userID)
+}
+
+// HandleCoinBalanceChanged copies the balance in a quiz.coin_balance_changed
+// event to the profile. The quiz service owns the balance; events that
+// arrive late or twice leave a newer copy alone.
+func (s *AuthService) HandleCoinBalanceChanged(ctx context.Context, payload json.RawMessage) error {
+	var event struct {
+		UserID  string `json:"user_id"`
+		Coins   int32  `json:"coins"`
+		Version int64  `json:"version"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.UserID == "" || event.Version <= 0 {
+		log.Printf("Ignoring malformed quiz.coin_balance_changed event: %s", payload)
+		return nil
+	}
+	return s.repo.SetCoins(ctx, event.UserID, event.Coins, event.Version)
+}
+
+// UpdateProfile applies the fields that are set. Nothing is written and no
+// event is emitted when neither field changes the profile.
+func (s *AuthService) UpdateProfile(ctx context.Context, userID string, nickname, avatarEmoji *string) (*repository.User, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if nickname != nil {
+		n := strings.TrimSpace(*nickname)
+		if err := validateNickname(n); err != nil {
+			return nil, err
+		}
+		if n != user.Nickname {
+			user.Nickname = n
+			changed = append(changed, "nickname")
+		}
+	}
+	if avatarEmoji != nil {
+		if err := validateAvatarEmoji(*avatarEmoji); err != nil {
+			return nil, err
+		}
+		if *avatarEmoji != user.AvatarEmoji {
+			user.AvatarEmoji = *avatarEmoji
+			changed = append(changed, "avatar_emoji")
+		}
+	}
+
+	if len(changed) == 0 {
+		return user, nil
+	}
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.events.Emit("user.profile_updated", map[string]interface{}{
+		"user_id":        user.ID,
+		"nickname":       user.Nickname,
+		"avatar_emoji":   user.AvatarEmoji,
+		"changed_fields": changed,
+		"updated_at":     user.UpdatedAt.Format(time.RFC3339),
+	})
+
+	return user, nil
+}
+
+func validateNickname(nickname string) error {
+	n := utf8.RuneCountInString(nickname)
+	if n < minNicknameLength || n > maxNicknameLength {
+		return ErrInvalidNickname
+	}
+	for _, r := range nickname {
+		if unicode.IsControl(r) {
+			return ErrInvalidNickname
+		}
+	}
+	return nil
+}
+
+func validateAvatarEmoji(avatar string) error {
+	if avatar == "" || utf8.RuneCountInString(avatar) > maxAvatarCodePoints {
+		return ErrInvalidAvatarEmoji
+	}
+	if uniseg.GraphemeClusterCount(avatar) != 1 {
+		return ErrInvalidAvatarEmoji
+	}
+
+	first, _ := utf8.DecodeRuneInString(avatar)
+	if !isEmojiRune(first) && !isKeycap(avatar) {
+		return ErrInvalidAvatarEmoji
+	}
+	return nil
+}
+
+func isEmojiRune(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, flags, symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols, dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF, r >= 0x2190 && r <= 0x21FF:
+		return true
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	}
+	return false
+}
+
+// isKeycap matches keycap sequences such as "1️⃣".
+func isKeycap(s string) bool {
+	return strings.HasSuffix(s, "⃣") && strings.ContainsAny(s[:1], "0123456789#*")
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/pawfiler/backend/pkg/authz"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+func (s *AuthService) GetUserRoles(ctx context.Context, userID string) (*repository.Grants, error) {
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.roles.GetGrants(ctx, userID)
+}
+
+// AssignRole grants role to userID. Changes reach the user's access token on
+// its next refresh.
+func (s *AuthService) AssignRole(ctx context.Context, actorID, userID, role string) (*repository.Grants, error) {
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	err := s.roles.Assign(ctx, userID, role)
+	if errors.Is(err, repository.ErrUnknownRole) {
+		return nil, ErrUnknownRole
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("user %s granted role %s to user %s", actorID, role, userID)
+	return s.roles.GetGrants(ctx, userID)
+}
+
+func (s *AuthService) RevokeRole(ctx context.Context, actorID, userID, role string) (*repository.Grants, error) {
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.roles.Revoke(ctx, userID, role); err != nil {
+		return nil, err
+	}
+
+	log.Printf("user %s revoked role %s from user %s", actorID, role, userID)
+	return s.roles.GetGrants(ctx, userID)
+}
+
+// BootstrapAdmins grants the admin role to the accounts with the given
+// emails, so a fresh deployment has someone who can assign roles. Accounts
+// must exist and have a verified email; others are skipped with a warning.
+func (s *AuthService) BootstrapAdmins(ctx context.Context, emails []string) error {
+	for _, email := range emails {
+		email = normalizeEmail(email)
+		if email == "" {
+			continue
+		}
+
+		user, err := s.repo.GetByEmail(ctx, email)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			log.Printf("admin bootstrap: no account for %s yet", email)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if user.EmailVerifiedAt == nil {
+			log.Printf("admin bootstrap: %s has not verified their email, skipping", email)
+			continue
+		}
+
+		if err := s.roles.Assign(ctx, user.ID, authz.RoleAdmin); err != nil {
+			return err
+		}
+		log.Printf("admin bootstrap: %s is an admin", email)
+	}
+	return nil
+}
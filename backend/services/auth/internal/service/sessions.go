@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+// ClientInfo describes the device a request came from. Either field may be
+// empty when unknown.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// maxUserAgentLength bounds what is stored from a client-supplied header.
+const maxUserAgentLength = 512
+
+func (s *AuthService) createSession(ctx context.Context, userID, sessionID string, client ClientInfo) error {
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	return s.sessions.Create(ctx, &repository.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		UserAgent: userAgent,
+		Device:    deviceLabel(userAgent),
+		IPAddress: client.IP,
+	})
+}
+
+// ListSessions returns the user's signed-in devices. Last-seen times advance
+// when the device refreshes its access token.
+func (s *AuthService) ListSessions(ctx context.Context, userID string) ([]*repository.Session, error) {
+	return s.sessions.ListActive(ctx, userID, time.Now().Add(-refreshTokenTTL))
+}
+
+// RevokeSession signs userID out of one of their sessions. Access tokens
+// already issued for it are rejected wherever sessions are checked.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.sessions.Get(ctx, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.revokeSession(ctx, sessionID)
+}
+
+func (s *AuthService) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.tokens.RevokeFamily(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.sessions.Revoke(ctx, sessionID)
+}
+
+// SessionRevoked implements jwtauth.SessionChecker. Sessions the table does
+// not know about predate it and are treated as live.
+func (s *AuthService) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	session, err := s.sessions.Get(ctx, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return session.RevokedAt != nil, nil
+}
+
+// RecentlyRevokedSessions lists sessions revoked within the access token
+// lifetime; older revocations no longer matter because every token issued
+// for them has expired.
+func (s *AuthService) RecentlyRevokedSessions(ctx context.Context) ([]string, error) {
+	return s.sessions.ListRevokedSince(ctx, time.Now().Add(-accessTokenTTL))
+}
+
+// deviceLabel turns a user agent into a short description such as
+// "Chrome on Windows" for the session list.
+func deviceLabel(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+	ua := strings.ToLower(userAgent)
+
+	browser := ""
+	for _, b := range []struct{ token, name string }{
+		{"edg/", "Edge"},
+		{"whale/", "Whale"},
+		{"samsungbrowser/", "Samsung Internet"},
+		{"kakaotalk", "KakaoTalk"},
+		{"firefox/", "Firefox"},
+		{"chrome/", "Chrome"},
+		{"safari/", "Safari"},
+		{"grpc-", "gRPC client"},
+	} {
+		if strings.Contains(ua, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	platform := ""
+	for _, o := range []struct{ token, name string }{
+		{"iphone", "iPhone"},
+		{"ipad", "iPad"},
+		{"android", "Android"},
+		{"windows", "Windows"},
+		{"mac os x", "macOS"},
+		{"cros", "ChromeOS"},
+		{"linux", "Linux"},
+	} {
+		if strings.Contains(ua, o.token) {
+			platform = o.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. The presented
+// token is revoked; presenting it again is treated as theft and revokes every
+// token in its session.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
+	current, err := s.tokens.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		if current.ReplacedBy != "" {
+			log.Printf("refresh token reuse detected for user %s, revoking session %s", current.UserID, current.FamilyID)
+			if err := s.revokeSession(ctx, current.FamilyID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.repo.GetByID(ctx, current.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, next, err := newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.tokens.Rotate(ctx, current.ID, next)
+	if errors.Is(err, repository.ErrRefreshTokenUsed) {
+		log.Printf("concurrent refresh token reuse for user %s, revoking session %s", current.UserID, current.FamilyID)
+		if err := s.revokeSession(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Touch(ctx, current.FamilyID, client.IP); err != nil {
+		return nil, err
+	}
+
+	access, err := s.generateToken(ctx, user, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{AccessToken: access, RefreshToken: raw, ExpiresIn: int64(accessTokenTTL.Seconds())}, nil
+}
+
+// Logout revokes the session the refresh token belongs to. Unknown tokens are
+// ignored so logging out twice is harmless.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	current, err := s.tokens.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.revokeSession(ctx, current.FamilyID)
+}
+
+// issueTokens starts a new session for user on client. Child accounts still waiting
+// for their guardian cannot sign in.
+func (s *AuthService) issueTokens(ctx context.Context, user *repository.User, client ClientInfo) (*TokenPair, error) {
+	if awaitingGuardian(user) {
+		return nil, ErrGuardianConsentPending
+	}
+	raw, token, err := newRefreshToken(user.ID, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createSession(ctx, user.ID, token.FamilyID, client); err != nil {
+		return nil, err
+	}
+	if err := s.tokens.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	access, err := s.generateToken(ctx, user, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{AccessToken: access, RefreshToken: raw, ExpiresIn: int64(accessTokenTTL.Seconds())}, nil
+}
+
+// generateToken signs an access token for user. The "role" claim predates
+// RBAC and carries the subscription type; authorization uses "roles" and
+// "permissions", which are reloaded on every refresh.
+func (s *AuthService) generateToken(ctx context.Context, user *repository.User, sessionID string) (string, error) {
+	grants, err := s.roles.GetGrants(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":         user.ID,
+		"sid":         sessionID,
+		"email":       user.Email,
+		"nickname":    user.Nickname,
+		"avatarEmoji": user.AvatarEmoji,
+		"role":        user.SubscriptionType,
+		"accountType": user.AccountType,
+		"exp":         now.Add(accessTokenTTL).Unix(),
+		"iat":         now.Unix(),
+	}
+	if len(user.Restrictions) > 0 {
+		claims["restrictions"] = user.Restrictions
+	}
+	if len(grants.Roles) > 0 {
+		claims["roles"] = grants.Roles
+		claims["permissions"] = grants.Permissions
+	}
+	return s.signer.Sign(claims)
+}
+
+func newRefreshToken(userID, familyID string) (string, *repository.RefreshToken, error) {
+	raw, err := randomToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return raw, &repository.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}, nil
+}
+
+// randomToken returns 256 random bits, URL-safe encoded.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/pawfiler/backend/services/auth/internal/signing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testSecret   = "test-secret"
+	testEmail    = "user@example.com"
+	testPassword = "correct horse"
+)
+
+type nopEmitter struct{}
+
+func (nopEmitter) Emit(eventType string, payload map[string]interface{}) error { return nil }
+
+// newTestService returns an AuthService backed by memory repositories with
+// one user, testEmail, whose password is testPassword.
+func newTestService(t *testing.T) (*AuthService, *repository.User) {
+	t.Helper()
+
+	users := repository.NewMemoryUserRepository()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &repository.User{Email: testEmail, PasswordHash: string(hash), Nickname: "tester"}
+	if err := users.Create(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewAuthService(
+		users,
+		repository.NewMemoryRefreshTokenRepository(),
+		signing.NewHMACSigner([]byte(testSecret)),
+		jwtauth.NewVerifier([]byte(testSecret)),
+		nopEmitter{},
+		NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), DefaultAccountPolicy, DefaultIPPolicy),
+		repository.NewMemoryRoleRepository(users),
+		repository.NewMemorySessionRepository(),
+		repository.NewMemoryTOTPRepository(),
+		repository.NewMemoryRecoveryCodeRepository(),
+		repository.NewMemoryAccountTokenRepository(),
+	)
+	return svc, user
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t)
+
+	first, _, err := svc.Login(ctx, testEmail, testPassword, ClientInfo{})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	second, err := svc.RefreshToken(ctx, first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("RefreshToken returned the same refresh token")
+	}
+	if second.AccessToken == "" {
+		t.Fatal("RefreshToken returned no access token")
+	}
+	if _, err := svc.RefreshToken(ctx, second.RefreshToken, ClientInfo{}); err != nil {
+		t.Fatalf("RefreshToken with rotated token: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t)
+
+	first, _, err := svc.Login(ctx, testEmail, testPassword, ClientInfo{})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	second, err := svc.RefreshToken(ctx, first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	if _, err := svc.RefreshToken(ctx, first.RefreshToken, ClientInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("reusing a rotated token: got %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	// The reuse may have come from whoever stole the token, so the token the
+	// legitimate client holds is revoked along with the rest of the session.
+	if _, err := svc.RefreshToken(ctx, second.RefreshToken, ClientInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("refreshing after reuse: got %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshTokenUnknown(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	if _, err := svc.RefreshToken(context.Background(), "not-a-token", ClientInfo{}); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
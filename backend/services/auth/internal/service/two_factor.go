@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/pawfiler/backend/services/auth/internal/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication not enabled")
+	ErrTwoFactorNotStarted     = errors.New("two-factor enrollment not started")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidChallenge        = errors.New("sign-in challenge invalid or expired")
+)
+
+const (
+	totpIssuer         = "Pawfiler"
+	challengeTTL       = 5 * time.Minute
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+// TwoFactorRequiredError is returned by Login and social login instead of
+// tokens when the account has two-factor authentication on. The client
+// finishes signing in by passing ChallengeToken and a code to
+// CompleteTwoFactorLogin.
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+	ExpiresIn      int64
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return "two-factor code required"
+}
+
+// TwoFactorStatus describes a user's two-factor setup.
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// requireSecondFactor returns a *TwoFactorRequiredError carrying a new
+// challenge if user has confirmed TOTP, and nil otherwise.
+func (s *AuthService) requireSecondFactor(ctx context.Context, user *repository.User) error {
+	if err := canSignIn(user); err != nil {
+		return err
+	}
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil || !enabled {
+		return err
+	}
+
+	raw, err := randomToken()
+	if err != nil {
+		return err
+	}
+	err = s.challenges.Create(ctx, &repository.AccountToken{
+		UserID:    user.ID,
+		Purpose:   repository.PurposeTwoFactor,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(challengeTTL),
+	})
+	if err != nil {
+		return err
+	}
+	return &TwoFactorRequiredError{ChallengeToken: raw, ExpiresIn: int64(challengeTTL.Seconds())}
+}
+
+// canSignIn applies the account checks every login path makes before
+// issuing tokens: a child account waiting for its guardian cannot sign in.
+func canSignIn(user *repository.User) error {
+	if awaitingGuardian(user) {
+		return ErrGuardianConsentPending
+	}
+	return nil
+}
+
+// CompleteTwoFactorLogin finishes a login that returned a
+// TwoFactorRequiredError. code may be a current TOTP code or an unused
+// recovery code. Wrong codes count towards the login throttle. The account
+// is checked again as it is now, since it may have changed since the
+// challenge was issued.
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string, client ClientInfo) (*TokenPair, *repository.User, error) {
+	challenge, err := s.challenges.Lookup(ctx, hashToken(challengeToken), repository.PurposeTwoFactor)
+	if errors.Is(err, repository.ErrAccountTokenInvalid) {
+		return nil, nil, ErrInvalidChallenge
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := s.repo.GetByID(ctx, challenge.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, nil, ErrInvalidChallenge
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := canSignIn(user); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkSecondFactor(ctx, user, code, true, client); err != nil {
+		return nil, nil, err
+	}
+
+	// Consuming the challenge last means two requests racing with valid codes
+	// cannot both get tokens.
+	if _, err := s.challenges.Consume(ctx, hashToken(challengeToken), repository.PurposeTwoFactor); err != nil {
+		if errors.Is(err, repository.ErrAccountTokenInvalid) {
+			return nil, nil, ErrInvalidChallenge
+		}
+		return nil, nil, err
+	}
+	if err := s.throttle.Success(ctx, user.Email); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+func (s *AuthService) TwoFactorStatus(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return &TwoFactorStatus{}, nil
+	}
+	remaining, err := s.recoveryCodes.CountUnused(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+// BeginTOTPEnrollment creates a new authenticator secret for the user after
+// confirming their password. It has no effect on login until confirmed with
+// ConfirmTOTPEnrollment; starting again replaces an unconfirmed secret.
+func (s *AuthService) BeginTOTPEnrollment(ctx context.Context, userID, password string, client ClientInfo) (secret, provisioningURI string, err error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.confirmPassword(ctx, user, password, client); err != nil {
+		return "", "", err
+	}
+
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	err = s.totp.SavePending(ctx, user.ID, secret)
+	if errors.Is(err, repository.ErrTOTPAlreadyEnabled) {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return secret, totp.ProvisioningURI(totpIssuer, user.Email, secret), nil
+}
+
+// ConfirmTOTPEnrollment turns two-factor login on once code shows the
+// user's app is set up, and returns their first set of recovery codes.
+func (s *AuthService) ConfirmTOTPEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	cred, err := s.totp.Get(ctx, userID)
+	if errors.Is(err, repository.ErrTOTPNotFound) {
+		return nil, ErrTwoFactorNotStarted
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cred.ConfirmedAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if err := s.useTOTPCode(ctx, cred, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.newRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("two-factor authentication enabled for user %s", userID)
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes. It takes an
+// authenticator code, not a recovery code, so a leaked recovery code cannot
+// be turned into a fresh set.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID, code string, client ClientInfo) ([]string, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSecondFactor(ctx, user, code, false, client); err != nil {
+		return nil, err
+	}
+	return s.newRecoveryCodes(ctx, user.ID)
+}
+
+// DisableTwoFactor turns two-factor login off. It needs both the password
+// and a current code or recovery code.
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID, password, code string, client ClientInfo) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.confirmPassword(ctx, user, password, client); err != nil {
+		return err
+	}
+	if err := s.checkSecondFactor(ctx, user, code, true, client); err != nil {
+		return err
+	}
+
+	if err := s.totp.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.recoveryCodes.DeleteAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	log.Printf("two-factor authentication disabled for user %s", user.ID)
+	return nil
+}
+
+func (s *AuthService) twoFactorEnabled(ctx context.Context, userID string) (bool, error) {
+	cred, err := s.totp.Get(ctx, userID)
+	if errors.Is(err, repository.ErrTOTPNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return cred.ConfirmedAt != nil, nil
+}
+
+// checkSecondFactor verifies code for user, counting wrong codes towards
+// the login throttle like wrong passwords.
+func (s *AuthService) checkSecondFactor(ctx context.Context, user *repository.User, code string, allowRecovery bool, client ClientInfo) error {
+	if err := s.throttle.Reserve(ctx, user.Email, client.IP); err != nil {
+		return err
+	}
+	err := s.verifySecondFactor(ctx, user.ID, code, allowRecovery)
+	if errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+	if releaseErr := s.throttle.Release(ctx, user.Email, client.IP); releaseErr != nil {
+		return releaseErr
+	}
+	return err
+}
+
+// verifySecondFactor accepts a TOTP code, or a recovery code when
+// allowRecovery is set, for a user with two-factor on.
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID, code string, allowRecovery bool) error {
+	cred, err := s.totp.Get(ctx, userID)
+	if errors.Is(err, repository.ErrTOTPNotFound) {
+		return ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	if cred.ConfirmedAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.Join(strings.Fields(code), "")
+	if len(code) == totp.Digits {
+		return s.useTOTPCode(ctx, cred, code)
+	}
+	if !allowRecovery {
+		return ErrInvalidTwoFactorCode
+	}
+
+	raw := []byte(normalizeRecoveryCode(code))
+	err = s.recoveryCodes.Use(ctx, userID, func(hash string) bool {
+		return bcrypt.CompareHashAndPassword([]byte(hash), raw) == nil
+	})
+	if errors.Is(err, repository.ErrRecoveryCodeInvalid) {
+		return ErrInvalidTwoFactorCode
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("recovery code used for user %s", userID)
+	return nil
+}
+
+// useTOTPCode checks code and records its time step so it cannot be used
+// again.
+func (s *AuthService) useTOTPCode(ctx context.Context, cred *repository.TOTPCredential, code string) error {
+	step, ok := totp.Validate(cred.Secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	err := s.totp.UseStep(ctx, cred.UserID, step)
+	if errors.Is(err, repository.ErrTOTPStepUsed) {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+// newRecoveryCodes generates and stores a fresh set of recovery codes such
+// as "k7x2m-q9dfa". Only their bcrypt hashes are kept, the same as
+// passwords.
+func (s *AuthService) newRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	enc := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(enc.EncodeToString(b)[:recoveryCodeLength])
+		codes[i] = raw[:recoveryCodeLength/2] + "-" + raw[recoveryCodeLength/2:]
+		hash, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = string(hash)
+	}
+	if err := s.recoveryCodes.Replace(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
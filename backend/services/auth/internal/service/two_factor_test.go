@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pawfiler/backend/services/auth/internal/totp"
+)
+
+// totpCode computes the code an authenticator app would show for secret at t.
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/int64(totp.Period.Seconds())))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// enableTwoFactor turns on TOTP for user and returns the secret and the code
+// used to confirm it.
+func enableTwoFactor(t *testing.T, svc *AuthService, userID string) (secret, code string) {
+	t.Helper()
+	ctx := context.Background()
+	secret, _, err := svc.BeginTOTPEnrollment(ctx, userID, testPassword, ClientInfo{})
+	if err != nil {
+		t.Fatalf("BeginTOTPEnrollment: %v", err)
+	}
+	code = totpCode(t, secret, time.Now())
+	if _, err := svc.ConfirmTOTPEnrollment(ctx, userID, code); err != nil {
+		t.Fatalf("ConfirmTOTPEnrollment: %v", err)
+	}
+	return secret, code
+}
+
+func loginChallenge(t *testing.T, svc *AuthService) string {
+	t.Helper()
+	_, _, err := svc.Login(context.Background(), testEmail, testPassword, ClientInfo{})
+	var required *TwoFactorRequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("Login: got %v, want *TwoFactorRequiredError", err)
+	}
+	return required.ChallengeToken
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	ctx := context.Background()
+	svc, user := newTestService(t)
+	secret, _ := enableTwoFactor(t, svc, user.ID)
+
+	challenge := loginChallenge(t, svc)
+	tokens, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, totpCode(t, secret, time.Now().Add(totp.Period)), ClientInfo{})
+	if err != nil {
+		t.Fatalf("CompleteTwoFactorLogin: %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Fatal("CompleteTwoFactorLogin returned no tokens")
+	}
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, totpCode(t, secret, time.Now().Add(totp.Period)), ClientInfo{}); !errors.Is(err, ErrInvalidChallenge) {
+		t.Fatalf("reusing the challenge: got %v, want %v", err, ErrInvalidChallenge)
+	}
+}
+
+func TestTwoFactorCodeCannotBeReplayed(t *testing.T) {
+	ctx := context.Background()
+	svc, user := newTestService(t)
+	_, code := enableTwoFactor(t, svc, user.ID)
+
+	challenge := loginChallenge(t, svc)
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, code, ClientInfo{}); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("replaying the enrollment code: got %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+}
+
+func TestTwoFactorWrongCodeCountsTowardsThrottle(t *testing.T) {
+	ctx := context.Background()
+	svc, user := newTestService(t)
+	secret, _ := enableTwoFactor(t, svc, user.ID)
+	challenge := loginChallenge(t, svc)
+
+	for {
+		_, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, "000000", ClientInfo{})
+		if errors.Is(err, ErrTooManyAttempts) {
+			break
+		}
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("wrong code: got %v, want %v", err, ErrInvalidTwoFactorCode)
+		}
+	}
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, totpCode(t, secret, time.Now().Add(totp.Period)), ClientInfo{}); !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("right code while throttled: got %v, want %v", err, ErrTooManyAttempts)
+	}
+}
+
+func TestTwoFactorRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	svc, user := newTestService(t)
+	secret, _, err := svc.BeginTOTPEnrollment(ctx, user.ID, testPassword, ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes, err := svc.ConfirmTOTPEnrollment(ctx, user.ID, totpCode(t, secret, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	challenge := loginChallenge(t, svc)
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, codes[0], ClientInfo{}); err != nil {
+		t.Fatalf("recovery code: %v", err)
+	}
+	challenge = loginChallenge(t, svc)
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, challenge, codes[0], ClientInfo{}); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("reusing a recovery code: got %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+}
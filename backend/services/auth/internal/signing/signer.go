@@ -0,0 +1,89 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pawfiler/backend/pkg/jwtauth"
+)
+
+type Signer interface {
+	Sign(claims jwt.Claims) (string, error)
+}
+
+// HMACSigner signs HS256 tokens with a shared secret. It is the fallback for
+// local development when no signing key file is configured.
+type HMACSigner struct {
+	secret []byte
+}
+
+func NewHMACSigner(secret []byte) *HMACSigner {
+	return &HMACSigner{secret: secret}
+}
+
+func (s *HMACSigner) Sign(claims jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+// KeySigner signs RS256 or EdDSA tokens and stamps them with the key's kid so
+// verifiers can pick the matching key from the JWKS.
+type KeySigner struct {
+	kid    string
+	key    crypto.Signer
+	method jwt.SigningMethod
+}
+
+func LoadKeySigner(path string) (*KeySigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	key, err := jwtauth.ParsePrivateKeyPEM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewKeySigner(key)
+}
+
+func NewKeySigner(key crypto.Signer) (*KeySigner, error) {
+	var method jwt.SigningMethod
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	kid, err := jwtauth.KeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{kid: kid, key: key, method: method}, nil
+}
+
+func (s *KeySigner) KeyID() string {
+	return s.kid
+}
+
+func (s *KeySigner) PublicKey() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *KeySigner) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.kid
+	return token.SignedString(s.key)
+}
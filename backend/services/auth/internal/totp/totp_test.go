@@ -0,0 +1,97 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key from RFC 6238 appendix B, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC lists eight-digit codes; these are their last six digits.
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+}
+
+func TestValidateRFCVectors(t *testing.T) {
+	for _, v := range rfcVectors {
+		now := time.Unix(v.unix, 0)
+		step, ok := Validate(rfcSecret, v.code, now)
+		if !ok {
+			t.Errorf("Validate(%s) at %d rejected", v.code, v.unix)
+			continue
+		}
+		if want := v.unix / int64(Period.Seconds()); step != want {
+			t.Errorf("Validate(%s) at %d matched step %d, want %d", v.code, v.unix, step, want)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code := "005924"
+
+	if step, ok := Validate(rfcSecret, code, now.Add(Period)); !ok || step != now.Unix()/30 {
+		t.Errorf("code from the previous step: step %d, ok %v", step, ok)
+	}
+	if step, ok := Validate(rfcSecret, code, now.Add(-Period)); !ok || step != now.Unix()/30 {
+		t.Errorf("code from the next step: step %d, ok %v", step, ok)
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(2*Period)); ok {
+		t.Error("code two steps old accepted")
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"wrong code", rfcSecret, "005925"},
+		{"too short", rfcSecret, "05924"},
+		{"eight digits", rfcSecret, "89005924"},
+		{"empty", rfcSecret, ""},
+		{"bad secret", "not base32!", "005924"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := Validate(tt.secret, tt.code, now); ok {
+				t.Fatalf("Validate(%q, %q) accepted", tt.secret, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateLowercaseSecret(t *testing.T) {
+	if _, ok := Validate(strings.ToLower(rfcSecret), "005924", time.Unix(1234567890, 0)); !ok {
+		t.Fatal("lowercase secret rejected")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Fatal("GenerateSecret returned the same secret twice")
+	}
+	key, err := encoding.DecodeString(a)
+	if err != nil || len(key) != 20 {
+		t.Fatalf("secret %q decodes to %d bytes, err %v", a, len(key), err)
+	}
+}
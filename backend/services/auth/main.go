@@ -1,75 +1,151 @@
+//go:generate protoc -I ../.. --go_out=. --go_opt=module=github.com/pawfiler/backend/services/auth --go-grpc_out=. --go-grpc_opt=module=github.com/pawfiler/backend/services/auth proto/auth.proto
+
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"database/sql"
 	"log"
 	"net/http"
-	"time"
+	"os"
+	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	_ "github.com/lib/pq"
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/auth/internal/export"
+	"github.com/pawfiler/backend/services/auth/internal/handler"
+	"github.com/pawfiler/backend/services/auth/internal/mailer"
+	"github.com/pawfiler/backend/services/auth/internal/oidc"
+	"github.com/pawfiler/backend/services/auth/internal/repository"
+	"github.com/pawfiler/backend/services/auth/internal/service"
+	"github.com/pawfiler/backend/services/auth/internal/signing"
+	pb "github.com/pawfiler/backend/services/auth/pb"
+	"github.com/pawfiler/backend/services/auth/pkg/kafka"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
+const devJWTSecret = "dev_jwt_secret_change_in_production"
 
-type SignupRequest struct {
-	Email       string `json:"email"`
-	Password    string `json:"password"`
-	Nickname    string `json:"nickname"`
-	AvatarEmoji string `json:"avatarEmoji"`
+func corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
-type UserProfile struct {
-	ID               string `json:"id"`
-	Email            string `json:"email"`
-	Nickname         string `json:"nickname"`
-	AvatarEmoji      string `json:"avatarEmoji"`
-	SubscriptionType string `json:"subscriptionType"`
-	Coins            int    `json:"coins"`
-	Level            int    `json:"level"`
-	LevelTitle       string `json:"levelTitle"`
-	XP               int    `json:"xp"`
-	CreatedAt        string `json:"createdAt"`
+// grpcOrHTTP routes native gRPC calls to the gRPC server and everything else
+// (including grpc-web+json from the browser) to the JSON gateway, so both
+// share port 50051.
+func grpcOrHTTP(grpcServer *grpc.Server, httpHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ct := r.Header.Get("Content-Type")
+		if r.ProtoMajor == 2 && strings.HasPrefix(ct, "application/grpc") && !strings.HasPrefix(ct, "application/grpc-web") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
 }
 
-type AuthResponse struct {
-	Token string      `json:"token"`
-	User  UserProfile `json:"user"`
+type repositories struct {
+	users         repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	loginAttempts repository.LoginAttemptRepository
+	accountTokens repository.AccountTokenRepository
+	identities    repository.IdentityRepository
+	oidcStates    repository.OIDCStateRepository
+	roles         repository.RoleRepository
+	sessions      repository.SessionRepository
+	totp          repository.TOTPRepository
+	recoveryCodes repository.RecoveryCodeRepository
+	outbox        repository.OutboxRepository
+	deletions     repository.AccountDeletionRepository
 }
 
-var jwtSecret = []byte("dev_jwt_secret_change_in_production")
+// newRepositories uses Postgres when DATABASE_URL is set and in-memory stores
+// otherwise. Login attempts are kept in memory unless
+// LOGIN_THROTTLE_STORE=postgres, which shares the limits across replicas.
+func newRepositories() repositories {
+	dbURL := os.Getenv("DATABASE_URL")
+	throttleStore := os.Getenv("LOGIN_THROTTLE_STORE")
 
-func generateToken(user UserProfile) (string, error) {
-	claims := jwt.MapClaims{
-		"sub":         user.ID,
-		"email":       user.Email,
-		"nickname":    user.Nickname,
-		"avatarEmoji": user.AvatarEmoji,
-		"role":        user.SubscriptionType,
-		"exp":         time.Now().Add(24 * time.Hour).Unix(),
-		"iat":         time.Now().Unix(),
+	if dbURL == "" {
+		if throttleStore == "postgres" {
+			log.Fatal("LOGIN_THROTTLE_STORE=postgres requires DATABASE_URL")
+		}
+		log.Println("DATABASE_URL not set, using in-memory user store")
+		users := repository.NewMemoryUserRepository()
+		seedDemoUser(users)
+		refreshTokens := repository.NewMemoryRefreshTokenRepository()
+		identities := repository.NewMemoryIdentityRepository()
+		sessions := repository.NewMemorySessionRepository()
+		outbox := repository.NewMemoryOutboxRepository()
+		return repositories{
+			users:         users,
+			refreshTokens: refreshTokens,
+			loginAttempts: repository.NewMemoryLoginAttemptRepository(),
+			accountTokens: repository.NewMemoryAccountTokenRepository(),
+			identities:    identities,
+			oidcStates:    repository.NewMemoryOIDCStateRepository(),
+			roles:         repository.NewMemoryRoleRepository(users),
+			sessions:      sessions,
+			totp:          repository.NewMemoryTOTPRepository(),
+			recoveryCodes: repository.NewMemoryRecoveryCodeRepository(),
+			outbox:        outbox,
+			deletions:     repository.NewMemoryAccountDeletionRepository(users, sessions, refreshTokens, identities, outbox),
+		}
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
-}
 
-func loginHandler(w http.ResponseWriter, r *http.Request) {
-	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
 	}
 
-	if req.Email != "detective@deepfind.io" || req.Password != "password123" {
-		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
-		return
+	repos := repositories{
+		users:         repository.NewPostgresUserRepository(db),
+		refreshTokens: repository.NewPostgresRefreshTokenRepository(db),
+		loginAttempts: repository.NewMemoryLoginAttemptRepository(),
+		accountTokens: repository.NewPostgresAccountTokenRepository(db),
+		identities:    repository.NewPostgresIdentityRepository(db),
+		oidcStates:    repository.NewPostgresOIDCStateRepository(db),
+		roles:         repository.NewPostgresRoleRepository(db),
+		sessions:      repository.NewPostgresSessionRepository(db),
+		totp:          repository.NewPostgresTOTPRepository(db),
+		recoveryCodes: repository.NewPostgresRecoveryCodeRepository(db),
+		outbox:        repository.NewPostgresOutboxRepository(db),
+		deletions:     repository.NewPostgresAccountDeletionRepository(db),
+	}
+	if throttleStore == "postgres" {
+		repos.loginAttempts = repository.NewPostgresLoginAttemptRepository(db)
 	}
+	return repos
+}
 
-	user := UserProfile{
-		ID:               "usr_001",
-		Email:            req.Email,
+// seedDemoUser mirrors the demo account inserted by scripts/init-db.sql so the
+// frontend's default login keeps working without Postgres.
+func seedDemoUser(repo repository.UserRepository) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	if err != nil {
+		log.Fatalf("failed to hash demo password: %v", err)
+	}
+	user := &repository.User{
+		Email:            "detective@deepfind.io",
+		PasswordHash:     string(hash),
 		Nickname:         "탐정",
 		AvatarEmoji:      "🦊",
 		SubscriptionType: "free",
@@ -77,78 +153,241 @@ func loginHandler(w http.ResponseWriter, r *http.Request) {
 		Level:            5,
 		LevelTitle:       "베테랑 탐정",
 		XP:               450,
-		CreatedAt:        time.Now().Format(time.RFC3339),
+		AccountType:      jwtauth.AccountTypeStandard,
 	}
-
-	token, err := generateToken(user)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	if err := repo.Create(context.Background(), user); err != nil {
+		log.Fatalf("failed to seed demo user: %v", err)
 	}
+	if err := repo.MarkEmailVerified(context.Background(), user.ID); err != nil {
+		log.Fatalf("failed to seed demo user: %v", err)
+	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(AuthResponse{Token: token, User: user})
+type eventEmitter interface {
+	service.EventEmitter
+	Close() error
 }
 
-func signupHandler(w http.ResponseWriter, r *http.Request) {
-	var req SignupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+// logEmitter stands in for Kafka when KAFKA_BROKERS is not configured.
+type logEmitter struct{}
 
-	if req.Email == "" || req.Password == "" || req.Nickname == "" {
-		http.Error(w, "Email, password, and nickname are required", http.StatusBadRequest)
-		return
+func (logEmitter) Emit(eventType string, payload map[string]interface{}) error {
+	log.Printf("Event (not published): %s %v", eventType, payload)
+	return nil
+}
+
+func (logEmitter) Close() error { return nil }
+
+func newEventEmitter() eventEmitter {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		log.Println("KAFKA_BROKERS not set, logging events instead of publishing")
+		return logEmitter{}
 	}
+	return kafka.NewProducer(brokers)
+}
 
-	if len(req.Password) < 6 {
-		http.Error(w, "Password must be at least 6 characters", http.StatusBadRequest)
-		return
+// newMailer writes mail to the log, or to .eml files under MAIL_DIR when set.
+func newMailer() mailer.Mailer {
+	dir := os.Getenv("MAIL_DIR")
+	if dir == "" {
+		return mailer.LogMailer{}
+	}
+	m, err := mailer.NewFileMailer(dir)
+	if err != nil {
+		log.Fatalf("failed to create mail directory: %v", err)
 	}
+	log.Printf("writing outgoing mail to %s", dir)
+	return m
+}
 
-	user := UserProfile{
-		ID:               "usr_new_001",
-		Email:            req.Email,
-		Nickname:         req.Nickname,
-		AvatarEmoji:      req.AvatarEmoji,
-		SubscriptionType: "free",
-		Coins:            100,
-		Level:            1,
-		LevelTitle:       "새싹 탐정",
-		XP:               0,
-		CreatedAt:        time.Now().Format(time.RFC3339),
+// newTokenKeys configures token signing. With JWT_SIGNING_KEY_FILE set, tokens
+// are signed with that RSA or Ed25519 key and verified against it plus any
+// keys in JWT_VERIFICATION_KEY_FILES (comma-separated), which keeps tokens
+// signed by a previous key valid during rotation. Otherwise HS256 with
+// JWT_SECRET is used and the JWKS is empty.
+func newTokenKeys() (signing.Signer, *jwtauth.Verifier, *jwtauth.StaticKeySet) {
+	keyFile := os.Getenv("JWT_SIGNING_KEY_FILE")
+	if keyFile == "" {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			log.Println("JWT_SECRET not set, using development secret")
+			secret = devJWTSecret
+		}
+		return signing.NewHMACSigner([]byte(secret)), jwtauth.NewVerifier([]byte(secret)), nil
 	}
 
-	token, err := generateToken(user)
+	signer, err := signing.LoadKeySigner(keyFile)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Fatalf("failed to load signing key: %v", err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(AuthResponse{Token: token, User: user})
+	keys := jwtauth.NewStaticKeySet()
+	if _, err := keys.Add(signer.PublicKey()); err != nil {
+		log.Fatalf("failed to register signing key: %v", err)
+	}
+	for _, path := range strings.Split(os.Getenv("JWT_VERIFICATION_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		pub, err := jwtauth.LoadPublicKeyFile(path)
+		if err != nil {
+			log.Fatalf("failed to load verification key: %v", err)
+		}
+		kid, err := keys.Add(pub)
+		if err != nil {
+			log.Fatalf("failed to register verification key %s: %v", path, err)
+		}
+		log.Printf("accepting tokens signed with key %s", kid)
+	}
+
+	log.Printf("signing tokens with key %s", signer.KeyID())
+	return signer, jwtauth.NewKeySetVerifier(keys), keys
 }
 
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+// newOIDCProviders loads social login providers from the JSON file at
+// OIDC_PROVIDERS_FILE, or from OIDC_PROVIDERS (comma-separated names) with
+// OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET, _REDIRECT_URL and optional
+// space-separated _SCOPES for each name.
+func newOIDCProviders() *oidc.Registry {
+	var configs []oidc.Config
+	if path := os.Getenv("OIDC_PROVIDERS_FILE"); path != "" {
+		var err error
+		configs, err = oidc.LoadConfigFile(path)
+		if err != nil {
+			log.Fatalf("failed to load OIDC providers: %v", err)
+		}
+	} else {
+		for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			prefix := "OIDC_" + strings.ToUpper(name) + "_"
+			configs = append(configs, oidc.Config{
+				Name:         name,
+				Issuer:       os.Getenv(prefix + "ISSUER"),
+				ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+				ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+				RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+				Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+			})
 		}
-		next(w, r)
 	}
+
+	providers, err := oidc.NewRegistry(configs)
+	if err != nil {
+		log.Fatalf("invalid OIDC provider config: %v", err)
+	}
+	if names := providers.Names(); len(names) > 0 {
+		log.Printf("social login enabled for: %s", strings.Join(names, ", "))
+	}
+	return providers
+}
+
+// newExportSources reads EXPORT_SOURCES, the other services' data export
+// endpoints as name=url pairs.
+func newExportSources() []export.Source {
+	sources, err := export.ParseSources(os.Getenv("EXPORT_SOURCES"))
+	if err != nil {
+		log.Fatalf("invalid EXPORT_SOURCES: %v", err)
+	}
+	return sources
 }
 
 func main() {
-	http.HandleFunc("/login", corsMiddleware(loginHandler))
-	http.HandleFunc("/signup", corsMiddleware(signupHandler))
+	repos := newRepositories()
+	signer, verifier, keys := newTokenKeys()
+	events := newEventEmitter()
+	defer events.Close()
+	throttle := service.NewLoginThrottle(repos.loginAttempts, service.DefaultAccountPolicy, service.DefaultIPPolicy)
+	authService := service.NewAuthService(repos.users, repos.refreshTokens, signer, verifier, events, throttle, repos.roles, repos.sessions, repos.totp, repos.recoveryCodes, repos.accountTokens)
+	// ADMIN_EMAILS (comma-separated) names verified accounts to make admins
+	// at startup, so a new deployment can hand out roles.
+	if err := authService.BootstrapAdmins(context.Background(), strings.Split(os.Getenv("ADMIN_EMAILS"), ",")); err != nil {
+		log.Fatalf("failed to bootstrap admins: %v", err)
+	}
+	appBaseURL := os.Getenv("APP_BASE_URL")
+	if appBaseURL == "" {
+		appBaseURL = "http://localhost:5173"
+	}
+	accountService := service.NewAccountService(repos.users, repos.accountTokens, repos.refreshTokens, repos.sessions, newMailer(), appBaseURL)
+	oidcService := service.NewOIDCService(newOIDCProviders(), repos.oidcStates, repos.identities, repos.users, authService)
+	// Events queued in the outbox with the change they report, such as
+	// user.deleted, are published from here.
+	relay := service.NewOutboxRelay(repos.outbox, events)
+	go relay.Run(context.Background())
+	// The profile's coins are a copy of the balance the quiz service owns.
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		consumer := kafka.NewConsumer(brokers, "auth-service")
+		defer consumer.Close()
+		consumer.Handle("quiz.coin_balance_changed", authService.HandleCoinBalanceChanged)
+		go func() {
+			if err := consumer.Run(context.Background()); err != nil {
+				log.Fatalf("event consumer stopped: %v", err)
+			}
+		}()
+	}
+	privacyService := service.NewPrivacyService(repos.users, repos.identities, repos.sessions, repos.roles, repos.deletions, relay, newExportSources(), authService)
+	// TRUSTED_PROXIES (comma-separated IPs or CIDRs) are the proxies, like
+	// Envoy, whose X-Forwarded-For names the client for login throttling.
+	proxies, err := handler.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+	authHandler := handler.NewAuthHandler(authService, accountService, oidcService, privacyService, proxies)
+
+	authOpts := []jwtauth.Option{
+		jwtauth.WithPublicMethods(
+			pb.AuthService_Login_FullMethodName,
+			pb.AuthService_Signup_FullMethodName,
+			pb.AuthService_ValidateToken_FullMethodName,
+			pb.AuthService_RefreshToken_FullMethodName,
+			pb.AuthService_Logout_FullMethodName,
+			pb.AuthService_VerifyEmail_FullMethodName,
+			pb.AuthService_RequestPasswordReset_FullMethodName,
+			pb.AuthService_ResetPassword_FullMethodName,
+			pb.AuthService_ListOIDCProviders_FullMethodName,
+			pb.AuthService_StartOIDCLogin_FullMethodName,
+			pb.AuthService_CompleteOIDCLogin_FullMethodName,
+			pb.AuthService_RequestGuardianLink_FullMethodName,
+			pb.AuthService_GetGuardianSettings_FullMethodName,
+			pb.AuthService_UpdateGuardianSettings_FullMethodName,
+			pb.AuthService_CompleteTwoFactorLogin_FullMethodName,
+			"/login",
+			"/signup",
+			"/refresh",
+			"/logout",
+			"/grpc.health.v1.Health/",
+			"/grpc.reflection.v1.ServerReflection/",
+			"/grpc.reflection.v1alpha.ServerReflection/",
+		),
+		jwtauth.WithSessionChecker(authService),
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(jwtauth.UnaryServerInterceptor(verifier, authOpts...)),
+		grpc.StreamInterceptor(jwtauth.StreamServerInterceptor(verifier, authOpts...)),
+	)
+	pb.RegisterAuthServiceServer(grpcServer, authHandler)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.AuthService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	mux := http.NewServeMux()
+	mux.Handle("/.well-known/jwks.json", handler.NewJWKSHandler(keys))
+	mux.Handle("/sessions/revoked", handler.NewRevokedSessionsHandler(authService))
+	mux.Handle("/", jwtauth.Middleware(verifier, authOpts...)(handler.NewJSONGateway(authHandler, proxies)))
+	gateway := corsMiddleware(mux)
+
+	server := &http.Server{
+		Addr:    ":50051",
+		Handler: h2c.NewHandler(grpcOrHTTP(grpcServer, gateway), &http2.Server{}),
+	}
 
-	log.Println("Auth service listening on :50051")
-	if err := http.ListenAndServe(":50051", nil); err != nil {
+	log.Println("Auth service listening on :50051 (gRPC + JSON)")
+	if err := server.ListenAndServe(); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
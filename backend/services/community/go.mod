@@ -1,15 +1,23 @@
 module community-service
 
-go 1.22
+go 1.23.0
 
 require (
+	github.com/lib/pq v1.10.9
+	github.com/pawfiler/backend/pkg v0.0.0
+	github.com/segmentio/kafka-go v0.4.47
 	google.golang.org/grpc v1.70.0
 	google.golang.org/protobuf v1.35.2
 )
 
 require (
-	golang.org/x/net v0.32.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.0 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20241202173237-19429a94021a // indirect
 )
+
+replace github.com/pawfiler/backend/pkg => ../../pkg
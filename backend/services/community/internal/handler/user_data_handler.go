@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"community-service/internal/repository"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+)
+
+// UserDataHandler serves GET /me/export, the community part of the caller's
+// personal data export that the auth service collects, and erases a user's
+// data when their account is deleted. ServeHTTP must sit behind
+// jwtauth.Middleware.
+type UserDataHandler struct {
+	repo *repository.UserDataRepository
+}
+
+func NewUserDataHandler(repo *repository.UserDataRepository) *UserDataHandler {
+	return &UserDataHandler{repo: repo}
+}
+
+func (h *UserDataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, ok := jwtauth.UserID(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	data, err := h.repo.ExportUserData(r.Context(), userID)
+	if err != nil {
+		log.Printf("community data export for user %s failed: %v", userID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(data)
+}
+
+// HandleUserDeleted erases a user's posts, comments and likes once auth
+// publishes user.deleted for them.
+func (h *UserDataHandler) HandleUserDeleted(ctx context.Context, payload json.RawMessage) error {
+	var event struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.UserID == "" {
+		log.Printf("Ignoring malformed user.deleted event: %s", payload)
+		return nil
+	}
+	if err := h.repo.DeleteUserData(ctx, event.UserID); err != nil {
+		return fmt.Errorf("delete community data for user %s: %w", event.UserID, err)
+	}
+	log.Printf("Deleted community data for user %s", event.UserID)
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type UserDataRepository struct {
+	db *sql.DB
+}
+
+func NewUserDataRepository(db *sql.DB) *UserDataRepository {
+	return &UserDataRepository{db: db}
+}
+
+type PostRecord struct {
+	ID             string    `json:"id"`
+	AuthorNickname string    `json:"author_nickname"`
+	AuthorEmoji    string    `json:"author_emoji"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	Tags           []string  `json:"tags"`
+	Likes          int32     `json:"likes"`
+	Comments       int32     `json:"comments"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CommentRecord struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"post_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LikeRecord struct {
+	PostID    string    `json:"post_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserData is everything the community schema stores about one user.
+type UserData struct {
+	Posts    []PostRecord    `json:"posts"`
+	Comments []CommentRecord `json:"comments"`
+	Likes    []LikeRecord    `json:"likes"`
+}
+
+func (r *UserDataRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	data := &UserData{Posts: []PostRecord{}, Comments: []CommentRecord{}, Likes: []LikeRecord{}}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, author_nickname, author_emoji, title, body, COALESCE(tags, '{}'), COALESCE(likes, 0),
+		        COALESCE(comments, 0), created_at
+		 FROM community.posts WHERE author_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p PostRecord
+		var tags pq.StringArray
+		if err := rows.Scan(&p.ID, &p.AuthorNickname, &p.AuthorEmoji, &p.Title, &p.Body, &tags, &p.Likes,
+			&p.Comments, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		p.Tags = tags
+		data.Posts = append(data.Posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	commentRows, err := r.db.QueryContext(ctx,
+		`SELECT id, post_id, content, created_at FROM community.comments WHERE author_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer commentRows.Close()
+	for commentRows.Next() {
+		var c CommentRecord
+		if err := commentRows.Scan(&c.ID, &c.PostID, &c.Content, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		data.Comments = append(data.Comments, c)
+	}
+	if err := commentRows.Err(); err != nil {
+		return nil, err
+	}
+
+	likeRows, err := r.db.QueryContext(ctx,
+		`SELECT post_id, created_at FROM community.likes WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer likeRows.Close()
+	for likeRows.Next() {
+		var l LikeRecord
+		if err := likeRows.Scan(&l.PostID, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		data.Likes = append(data.Likes, l)
+	}
+	return data, likeRows.Err()
+}
+
+// DeleteUserData erases the user's posts, with the comments and likes on
+// them, and their comments and likes on other posts, whose counters drop
+// to match. It is safe to repeat.
+func (r *UserDataRepository) DeleteUserData(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE community.posts p SET likes = GREATEST(COALESCE(p.likes, 0) - 1, 0)
+		 FROM community.likes l
+		 WHERE l.post_id = p.id AND l.user_id = $1 AND p.author_id <> $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM community.likes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE community.posts p SET comments = GREATEST(COALESCE(p.comments, 0) - c.n, 0)
+		 FROM (SELECT post_id, COUNT(*) AS n FROM community.comments WHERE author_id = $1 GROUP BY post_id) c
+		 WHERE c.post_id = p.id AND p.author_id <> $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM community.comments WHERE author_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM community.posts WHERE author_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
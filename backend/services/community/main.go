@@ -1,11 +1,23 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"log"
 	"net/http"
+	"os"
+
+	"community-service/internal/handler"
+	"community-service/internal/repository"
+	"community-service/pkg/kafka"
+
+	_ "github.com/lib/pq"
+	"github.com/pawfiler/backend/pkg/jwtauth"
 )
 
+const devJWTSecret = "dev_jwt_secret_change_in_production"
+
 type Post struct {
 	ID             string   `json:"id"`
 	AuthorNickname string   `json:"authorNickname"`
@@ -47,10 +59,79 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+func openDB() *sql.DB {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	return db
+}
+
+func kafkaBrokers() string {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		log.Println("KAFKA_BROKERS not set, using localhost:9092")
+		brokers = "localhost:9092"
+	}
+	return brokers
+}
+
+// newVerifier checks access tokens against the auth service's JWKS when
+// JWKS_URL is set, and with the shared JWT_SECRET otherwise.
+func newVerifier() *jwtauth.Verifier {
+	if url := os.Getenv("JWKS_URL"); url != "" {
+		return jwtauth.NewKeySetVerifier(jwtauth.NewRemoteKeySet(url))
+	}
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		log.Println("JWT_SECRET not set, using development secret")
+		secret = devJWTSecret
+	}
+	return jwtauth.NewVerifier([]byte(secret))
+}
+
 func main() {
-	http.HandleFunc("/community.CommunityService/GetFeed", corsMiddleware(getFeedHandler))
+	db := openDB()
+	defer db.Close()
+	userData := handler.NewUserDataHandler(repository.NewUserDataRepository(db))
+
+	// A deleted user's posts, comments and likes go when auth announces it.
+	consumer := kafka.NewConsumer(kafkaBrokers(), "community-service")
+	defer consumer.Close()
+	consumer.Handle("user.deleted", userData.HandleUserDeleted)
+	go func() {
+		if err := consumer.Run(context.Background()); err != nil {
+			log.Fatalf("event consumer stopped: %v", err)
+		}
+	}()
+
+	// Requests act as the user in their access token, never as a user_id
+	// they carry.
+	authOpts := []jwtauth.Option{
+		// The feed is readable without signing in.
+		jwtauth.WithPublicMethods("/community.CommunityService/GetFeed"),
+	}
+	// AUTH_REVOKED_SESSIONS_URL rejects tokens from signed-out sessions
+	// straight away instead of when they expire.
+	if url := os.Getenv("AUTH_REVOKED_SESSIONS_URL"); url != "" {
+		authOpts = append(authOpts, jwtauth.WithSessionChecker(jwtauth.NewRevocationList(url)))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/community.CommunityService/GetFeed", getFeedHandler)
+	// The personal data export endpoint the auth service collects from.
+	mux.Handle("/me/export", userData)
+	authenticated := jwtauth.Middleware(newVerifier(), authOpts...)(mux)
+
 	log.Println("Community service listening on :50053")
-	if err := http.ListenAndServe(":50053", nil); err != nil {
+	if err := http.ListenAndServe(":50053", corsMiddleware(authenticated.ServeHTTP)); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// anonymousUserID replaces the user on payment records that have to be
+// kept after their account is deleted.
+const anonymousUserID = "00000000-0000-0000-0000-000000000000"
+
+type UserDataRepository struct {
+	db *sql.DB
+}
+
+func NewUserDataRepository(db *sql.DB) *UserDataRepository {
+	return &UserDataRepository{db: db}
+}
+
+type SubscriptionRecord struct {
+	ID        string    `json:"id"`
+	PlanID    string    `json:"plan_id"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type TransactionRecord struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Amount         string    `json:"amount"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	PaymentMethod  string    `json:"payment_method"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// UserData is everything the payment schema stores about one user.
+type UserData struct {
+	Subscriptions []SubscriptionRecord `json:"subscriptions"`
+	Transactions  []TransactionRecord  `json:"transactions"`
+}
+
+func (r *UserDataRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	data := &UserData{Subscriptions: []SubscriptionRecord{}, Transactions: []TransactionRecord{}}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, plan_id, COALESCE(status, ''), started_at, expires_at
+		 FROM payment.subscriptions WHERE user_id = $1 ORDER BY started_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s SubscriptionRecord
+		if err := rows.Scan(&s.ID, &s.PlanID, &s.Status, &s.StartedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		data.Subscriptions = append(data.Subscriptions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	txRows, err := r.db.QueryContext(ctx,
+		`SELECT id, COALESCE(subscription_id::text, ''), amount::text, COALESCE(currency, ''), COALESCE(status, ''),
+		        COALESCE(payment_method, ''), created_at
+		 FROM payment.transactions WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer txRows.Close()
+	for txRows.Next() {
+		var t TransactionRecord
+		if err := txRows.Scan(&t.ID, &t.SubscriptionID, &t.Amount, &t.Currency, &t.Status, &t.PaymentMethod,
+			&t.CreatedAt); err != nil {
+			return nil, err
+		}
+		data.Transactions = append(data.Transactions, t)
+	}
+	return data, txRows.Err()
+}
+
+// DeleteUserData erases the user's subscriptions. Their transactions are
+// kept as accounting records, detached from the subscriptions and from the
+// user. It is safe to repeat.
+func (r *UserDataRepository) DeleteUserData(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE payment.transactions SET user_id = $2, subscription_id = NULL WHERE user_id = $1`,
+		userID, anonymousUserID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM payment.subscriptions WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
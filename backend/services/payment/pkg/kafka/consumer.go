@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// HandlerFunc processes the payload of one event.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Consumer reads pawfiler-events as part of a consumer group and dispatches
+// the event types it has handlers for. Offsets are committed only after the
+// handler succeeds, so a failed event is retried.
+type Consumer struct {
+	reader   *kafka.Reader
+	handlers map[string]HandlerFunc
+}
+
+func NewConsumer(brokers, groupID string) *Consumer {
+	return &Consumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: []string{brokers},
+			GroupID: groupID,
+			Topic:   "pawfiler-events",
+		}),
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+func (c *Consumer) Handle(eventType string, h HandlerFunc) {
+	c.handlers[eventType] = h
+}
+
+// Run consumes until ctx is cancelled.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		var event struct {
+			EventType string          `json:"event_type"`
+			Payload   json.RawMessage `json:"payload"`
+		}
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			log.Printf("Skipping malformed event at offset %d: %v", msg.Offset, err)
+		} else if h, ok := c.handlers[event.EventType]; ok {
+			if !c.handle(ctx, h, event.Payload) {
+				// Cancelled; the uncommitted event is delivered again.
+				return nil
+			}
+			log.Printf("Event handled: %s", event.EventType)
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+}
+
+// handle retries h with backoff until it succeeds, or reports false if ctx
+// is cancelled first.
+func (c *Consumer) handle(ctx context.Context, h HandlerFunc, payload json.RawMessage) bool {
+	backoff := time.Second
+	for {
+		err := h(ctx, payload)
+		if err == nil {
+			return true
+		}
+		log.Printf("Failed to handle event, retrying in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,193 @@
+// Command quizctl imports and exports quiz question banks through the quiz
+// service's ImportQuestions and ExportQuestions RPCs. It needs an access
+// token with the quiz.questions.write permission in QUIZCTL_TOKEN or -token.
+//
+//	quizctl import [-dry-run] [-format csv|jsonl] questions.csv
+//	quizctl export [-format csv|jsonl] [-status published] [-difficulty easy] [-tag cats] [-o questions.csv]
+//
+// The format defaults to the file extension, or jsonl.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const maxMessageSize = 16 << 20
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+  quizctl import [flags] FILE
+  quizctl export [flags]
+
+Run "quizctl import -h" or "quizctl export -h" for flags.`)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			err = fmt.Errorf("%s: %s", st.Code(), st.Message())
+		}
+		fmt.Fprintln(os.Stderr, "quizctl:", err)
+		os.Exit(1)
+	}
+}
+
+type connFlags struct {
+	addr  *string
+	token *string
+}
+
+func addConnFlags(fs *flag.FlagSet) connFlags {
+	addr := os.Getenv("QUIZCTL_ADDR")
+	if addr == "" {
+		addr = "localhost:50052"
+	}
+	return connFlags{
+		addr:  fs.String("addr", addr, "quiz service gRPC address (QUIZCTL_ADDR)"),
+		token: fs.String("token", os.Getenv("QUIZCTL_TOKEN"), "access token (QUIZCTL_TOKEN)"),
+	}
+}
+
+func (c connFlags) dial() (pb.QuizServiceClient, context.Context, func(), error) {
+	if *c.token == "" {
+		return nil, nil, nil, fmt.Errorf("an access token is required, set QUIZCTL_TOKEN or -token")
+	}
+	conn, err := grpc.NewClient(*c.addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(maxMessageSize), grpc.MaxCallRecvMsgSize(maxMessageSize)),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*c.token)
+	return pb.NewQuizServiceClient(conn), ctx, func() { cancel(); conn.Close() }, nil
+}
+
+// formatFor picks the format from the flag or the file extension.
+func formatFor(flagValue, path string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return "csv"
+	}
+	return "jsonl"
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	format := fs.String("format", "", "csv or jsonl (default from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate and report without saving")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("import takes exactly one file")
+	}
+	path := fs.Arg(0)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	client, ctx, done, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ImportQuestions(ctx, &pb.ImportQuestionsRequest{
+		Format: formatFor(*format, path),
+		Data:   data,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range resp.Errors {
+		key := ""
+		if e.ExternalKey != "" {
+			key = " (" + e.ExternalKey + ")"
+		}
+		fmt.Printf("%s:%d%s: %s\n", path, e.Row, key, e.Message)
+	}
+	switch {
+	case len(resp.Errors) > 0:
+		return fmt.Errorf("%d invalid rows, nothing imported", len(resp.Errors))
+	case resp.Applied:
+		fmt.Printf("imported: %d created, %d updated, %d unchanged\n", resp.Created, resp.Updated, resp.Unchanged)
+	case *dryRun:
+		fmt.Printf("dry run: would create %d, update %d, leave %d unchanged\n", resp.Created, resp.Updated, resp.Unchanged)
+	default:
+		fmt.Println("nothing to import")
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	format := fs.String("format", "", "csv or jsonl (default from -o, or jsonl)")
+	out := fs.String("o", "", "output file (default stdout)")
+	statusFilter := fs.String("status", "", "only questions with this status")
+	difficulty := fs.String("difficulty", "", "only questions of this difficulty")
+	tag := fs.String("tag", "", "only questions with this tag")
+	fs.Parse(args)
+
+	req := &pb.ExportQuestionsRequest{Format: formatFor(*format, *out)}
+	if *statusFilter != "" {
+		req.Status = statusFilter
+	}
+	if *difficulty != "" {
+		req.Difficulty = difficulty
+	}
+	if *tag != "" {
+		req.Tag = tag
+	}
+
+	client, ctx, done, err := conn.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ExportQuestions(ctx, req)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(resp.Data)
+		return err
+	}
+	if err := os.WriteFile(*out, resp.Data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d questions to %s\n", resp.Count, *out)
+	return nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/quiz/internal/service"
+)
+
+// ExportHandler serves GET /me/export, the quiz part of the caller's personal
+// data export that the auth service collects. It must sit behind
+// jwtauth.Middleware.
+type ExportHandler struct {
+	service *service.QuizService
+}
+
+func NewExportHandler(svc *service.QuizService) *ExportHandler {
+	return &ExportHandler{service: svc}
+}
+
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, ok := jwtauth.UserID(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	data, err := h.service.ExportUserData(r.Context(), userID)
+	if err != nil {
+		log.Printf("quiz data export for user %s failed: %v", userID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(data)
+}
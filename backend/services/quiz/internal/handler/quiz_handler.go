@@ -2,9 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log"
 
-	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
 	"github.com/pawfiler/backend/services/quiz/internal/service"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type QuizHandler struct {
@@ -16,18 +24,281 @@ func NewQuizHandler(svc *service.QuizService) *QuizHandler {
 	return &QuizHandler{service: svc}
 }
 
-func (h *QuizHandler) GetRandomQuestion(ctx context.Context, req *pb.GetRandomQuestionRequest) (*pb.QuizQuestion, error) {
-	return h.service.GetRandomQuestion(ctx, req.UserId, req.Difficulty)
+func (h *QuizHandler) GetRandomQuestion(ctx context.Context, req *pb.GetRandomQuestionRequest) (*pb.Question, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := h.service.GetRandomQuestion(ctx, userID, req.Difficulty)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
 }
 
 func (h *QuizHandler) SubmitAnswer(ctx context.Context, req *pb.SubmitAnswerRequest) (*pb.SubmitAnswerResponse, error) {
-	return h.service.SubmitAnswer(ctx, req.UserId, req.QuestionId, req.SelectedIndex)
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.service.SubmitAnswer(ctx, userID, subscription(ctx), req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
 }
 
 func (h *QuizHandler) GetUserStats(ctx context.Context, req *pb.GetUserStatsRequest) (*pb.QuizStats, error) {
-	return h.service.GetUserStats(ctx, req.UserId)
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.service.GetUserStats(ctx, userID, subscription(ctx), req.Days)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return stats, nil
+}
+
+func (h *QuizHandler) GetAnswerHistory(ctx context.Context, req *pb.GetAnswerHistoryRequest) (*pb.GetAnswerHistoryResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.service.GetAnswerHistory(ctx, userID, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (h *QuizHandler) GetQuestionById(ctx context.Context, req *pb.GetQuestionByIdRequest) (*pb.Question, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := h.service.GetQuestionById(ctx, userID, req.QuestionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
+}
+
+func (h *QuizHandler) GetReviewQuestion(ctx context.Context, req *pb.GetReviewQuestionRequest) (*pb.Question, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := h.service.GetReviewQuestion(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
+}
+
+func (h *QuizHandler) RefillLives(ctx context.Context, req *pb.RefillLivesRequest) (*pb.RefillLivesResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.service.RefillLives(ctx, userID, subscription(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (h *QuizHandler) StartTimedSession(ctx context.Context, req *pb.StartTimedSessionRequest) (*pb.TimedSession, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := h.service.StartTimedSession(ctx, userID, subscription(ctx), req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return session, nil
+}
+
+func (h *QuizHandler) SubmitTimedAnswer(ctx context.Context, req *pb.SubmitTimedAnswerRequest) (*pb.TimedAnswerResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.SubmitTimedAnswer(ctx, userID, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return result, nil
+}
+
+func (h *QuizHandler) FinishTimedSession(ctx context.Context, req *pb.FinishTimedSessionRequest) (*pb.TimedSessionResult, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.service.FinishTimedSession(ctx, userID, req.SessionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return result, nil
+}
+
+func (h *QuizHandler) GetTimedLeaderboard(ctx context.Context, req *pb.GetTimedLeaderboardRequest) (*pb.TimedLeaderboard, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	board, err := h.service.GetTimedLeaderboard(ctx, userID, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return board, nil
 }
 
-func (h *QuizHandler) GetQuestionById(ctx context.Context, req *pb.GetQuestionByIdRequest) (*pb.QuizQuestion, error) {
-	return h.service.GetQuestionById(ctx, req.QuestionId)
+func (h *QuizHandler) CreateQuestion(ctx context.Context, req *pb.CreateQuestionRequest) (*pb.QuizQuestion, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := h.service.CreateQuestion(ctx, userID, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
+}
+
+func (h *QuizHandler) UpdateQuestion(ctx context.Context, req *pb.UpdateQuestionRequest) (*pb.QuizQuestion, error) {
+	question, err := h.service.UpdateQuestion(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
+}
+
+func (h *QuizHandler) ArchiveQuestion(ctx context.Context, req *pb.ArchiveQuestionRequest) (*pb.QuizQuestion, error) {
+	question, err := h.service.ArchiveQuestion(ctx, req.QuestionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return question, nil
+}
+
+func (h *QuizHandler) ListQuestions(ctx context.Context, req *pb.ListQuestionsRequest) (*pb.ListQuestionsResponse, error) {
+	resp, err := h.service.ListQuestions(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (h *QuizHandler) ImportQuestions(ctx context.Context, req *pb.ImportQuestionsRequest) (*pb.ImportQuestionsResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.service.ImportQuestions(ctx, userID, req.Format, req.Data, req.DryRun)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (h *QuizHandler) ExportQuestions(ctx context.Context, req *pb.ExportQuestionsRequest) (*pb.ExportQuestionsResponse, error) {
+	resp, err := h.service.ExportQuestions(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// currentUser returns the user id from the verified access token. The user_id
+// fields on requests are ignored so clients cannot act as someone else.
+func currentUser(ctx context.Context) (string, error) {
+	userID, ok := jwtauth.UserID(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return userID, nil
+}
+
+// subscription is the caller's subscription type, which access tokens carry
+// as their role.
+func subscription(ctx context.Context) string {
+	if claims, ok := jwtauth.FromContext(ctx); ok {
+		return claims.Role
+	}
+	return ""
+}
+
+func toStatus(err error) error {
+	var noLives *repository.NoLivesLeftError
+	if errors.As(err, &noLives) {
+		st := status.New(codes.ResourceExhausted, "No lives left")
+		if detailed, derr := st.WithDetails(
+			&errdetails.ErrorInfo{Reason: "NO_LIVES_LEFT", Domain: "quiz.pawfiler"},
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(noLives.RetryAfter)},
+		); derr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrQuestionNotFound):
+		return status.Error(codes.NotFound, "Question not found")
+	case errors.Is(err, repository.ErrNoReviewsDue):
+		return status.Error(codes.NotFound, "No reviews due")
+	case errors.Is(err, repository.ErrLivesFull):
+		return status.Error(codes.FailedPrecondition, "Lives are already full")
+	case errors.Is(err, repository.ErrNotEnoughCoins):
+		return status.Error(codes.FailedPrecondition, "Not enough coins")
+	case errors.Is(err, repository.ErrTimedSessionNotFound):
+		return status.Error(codes.NotFound, "Timed session not found")
+	case errors.Is(err, repository.ErrTimedSessionOver):
+		return status.Error(codes.FailedPrecondition, "This timed session is over")
+	case errors.Is(err, repository.ErrNotCurrentQuestion):
+		return status.Error(codes.FailedPrecondition, "This is not the session's current question")
+	case errors.Is(err, repository.ErrInvalidLeaderboardPeriod):
+		return status.Error(codes.InvalidArgument, "Leaderboard period must be day, week or all")
+	case errors.Is(err, service.ErrInvalidIdempotencyKey):
+		return status.Error(codes.InvalidArgument, "Idempotency key must be 1 to 100 characters")
+	case errors.Is(err, repository.ErrIdempotencyKeyReused):
+		return status.Error(codes.InvalidArgument, "Idempotency key was already used for another question")
+	case errors.Is(err, repository.ErrAttemptInvalid):
+		return status.Error(codes.FailedPrecondition, "This question was not served to you or has already been answered")
+	case errors.Is(err, repository.ErrQuestionArchived):
+		return status.Error(codes.FailedPrecondition, "Archived questions cannot be changed")
+	case errors.Is(err, service.ErrMissingQuestionFields):
+		return status.Error(codes.InvalidArgument, "Video URL, thumbnail and explanation are required")
+	case errors.Is(err, service.ErrInvalidVideoURL):
+		return status.Error(codes.InvalidArgument, "Video URL must be an http or https URL")
+	case errors.Is(err, service.ErrInvalidOptions):
+		return status.Error(codes.InvalidArgument, "A question needs 2 to 6 distinct, non-empty options")
+	case errors.Is(err, service.ErrCorrectIndexOutOfRange):
+		return status.Error(codes.InvalidArgument, "Correct index must point at one of the options")
+	case errors.Is(err, service.ErrInvalidDifficulty):
+		return status.Error(codes.InvalidArgument, "Difficulty must be easy, medium or hard")
+	case errors.Is(err, service.ErrInvalidCategory):
+		return status.Error(codes.InvalidArgument, "Category must be authentic, face_swap, lip_sync, face_reenactment, synthetic or edited")
+	case errors.Is(err, service.ErrInvalidTags):
+		return status.Error(codes.InvalidArgument, "Use at most 10 tags of up to 30 characters")
+	case errors.Is(err, service.ErrTagHasSeparator):
+		return status.Error(codes.InvalidArgument, "Tags cannot contain \";\"")
+	case errors.Is(err, service.ErrInvalidStatus):
+		return status.Error(codes.InvalidArgument, "Status must be draft or published")
+	case errors.Is(err, service.ErrInvalidExternalKey):
+		return status.Error(codes.InvalidArgument, "External key must be up to 100 letters, digits, '.', '_', ':' or '-'")
+	case errors.Is(err, repository.ErrExternalKeyTaken):
+		return status.Error(codes.AlreadyExists, "Another question already has this external key")
+	case errors.Is(err, service.ErrUnknownBankFormat):
+		return status.Error(codes.InvalidArgument, "Format must be jsonl or csv")
+	case errors.Is(err, service.ErrImportTooLarge):
+		return status.Error(codes.InvalidArgument, "Import files are limited to 10 MB and 5000 questions")
+	case errors.Is(err, service.ErrInvalidImportFile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Printf("quiz request failed: %v", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
 }
@@ -0,0 +1,52 @@
+// Package lives works out how many lives a player has. A wrong answer costs
+// a life and lost lives come back one at a time; rather than a job topping
+// them up, the count is brought up to date from when it last changed
+// whenever it is read.
+package lives
+
+import "time"
+
+// Policy is how many lives a player can hold and how fast lost ones come
+// back.
+type Policy struct {
+	Max   int32
+	Every time.Duration
+}
+
+var (
+	Free    = Policy{Max: 3, Every: 30 * time.Minute}
+	Premium = Policy{Max: 5, Every: 10 * time.Minute}
+)
+
+// For returns the policy for a subscription type as carried in access
+// tokens.
+func For(subscription string) Policy {
+	if subscription == "premium" {
+		return Premium
+	}
+	return Free
+}
+
+// Regenerate returns how many lives a player who had lives at since has at
+// now, and the new since: when the countdown to the next life started, or
+// now once they are full. Players above Max, say after their premium
+// ended, keep their lives but do not regenerate.
+func (p Policy) Regenerate(lives int32, since, now time.Time) (int32, time.Time) {
+	if lives >= p.Max {
+		return lives, now
+	}
+	n := int32(max(now.Sub(since)/p.Every, 0))
+	if lives+n >= p.Max {
+		return p.Max, now
+	}
+	return lives + n, since.Add(time.Duration(n) * p.Every)
+}
+
+// NextIn is how long until the next life for lives and since as returned
+// by Regenerate, or 0 when full.
+func (p Policy) NextIn(lives int32, since, now time.Time) time.Duration {
+	if lives >= p.Max {
+		return 0
+	}
+	return since.Add(p.Every).Sub(now)
+}
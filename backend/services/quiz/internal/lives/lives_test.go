@@ -0,0 +1,62 @@
+package lives
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegenerate(t *testing.T) {
+	p := Policy{Max: 3, Every: 30 * time.Minute}
+	since := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		lives     int32
+		after     time.Duration
+		wantLives int32
+		wantSince time.Time
+	}{
+		{"no time passed", 1, 0, 1, since},
+		{"part of a life", 1, 29 * time.Minute, 1, since},
+		{"one life", 1, 30 * time.Minute, 2, since.Add(30 * time.Minute)},
+		{"keeps progress to next life", 0, 70 * time.Minute, 2, since.Add(60 * time.Minute)},
+		{"fills up", 0, 90 * time.Minute, 3, since.Add(90 * time.Minute)},
+		{"capped at max", 0, 10 * time.Hour, 3, since.Add(10 * time.Hour)},
+		{"already full", 3, time.Minute, 3, since.Add(time.Minute)},
+		{"above max keeps lives", 5, 10 * time.Hour, 5, since.Add(10 * time.Hour)},
+		{"clock behind since", 1, -time.Hour, 1, since},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lives, gotSince := p.Regenerate(tt.lives, since, since.Add(tt.after))
+			if lives != tt.wantLives || !gotSince.Equal(tt.wantSince) {
+				t.Fatalf("Regenerate = %d, %s; want %d, %s", lives, gotSince, tt.wantLives, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestNextIn(t *testing.T) {
+	p := Policy{Max: 3, Every: 30 * time.Minute}
+	since := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	now := since.Add(70 * time.Minute)
+	lives, newSince := p.Regenerate(0, since, now)
+	if got := p.NextIn(lives, newSince, now); got != 20*time.Minute {
+		t.Fatalf("NextIn = %s, want 20m", got)
+	}
+	if got := p.NextIn(3, since, now); got != 0 {
+		t.Fatalf("NextIn when full = %s, want 0", got)
+	}
+}
+
+func TestFor(t *testing.T) {
+	if For("premium") != Premium {
+		t.Error(`For("premium") is not Premium`)
+	}
+	for _, sub := range []string{"free", "", "unknown"} {
+		if For(sub) != Free {
+			t.Errorf("For(%q) is not Free", sub)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package questionbank reads and writes question bank files: JSON Lines with
+// one question object per line, or CSV with a header row as kept in
+// spreadsheets. Decoding only checks the file's shape; whether a question is
+// playable is up to the caller.
+package questionbank
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// MaxOptions is the number of option_N columns in CSV files.
+const MaxOptions = 6
+
+// TagSeparator joins tags in a single CSV cell, so tags cannot contain it.
+const TagSeparator = ";"
+
+var ErrUnknownFormat = errors.New("unknown question bank format")
+
+// Row is one question read from a file. Line is where it starts, counting
+// from 1. Err is set instead of Question when the row could not be read.
+type Row struct {
+	Line     int
+	Question *pb.QuizQuestion
+	Err      error
+}
+
+// record is the JSON Lines schema.
+type record struct {
+	ExternalKey    string   `json:"external_key"`
+	VideoURL       string   `json:"video_url"`
+	ThumbnailEmoji string   `json:"thumbnail_emoji"`
+	Options        []string `json:"options"`
+	CorrectIndex   *int32   `json:"correct_index"`
+	Explanation    string   `json:"explanation"`
+	Difficulty     string   `json:"difficulty"`
+	Category       string   `json:"category,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Status         string   `json:"status,omitempty"`
+}
+
+func (r *record) question() (*pb.QuizQuestion, error) {
+	if r.CorrectIndex == nil {
+		return nil, errors.New("correct_index is required")
+	}
+	return &pb.QuizQuestion{
+		ExternalKey:    r.ExternalKey,
+		VideoUrl:       r.VideoURL,
+		ThumbnailEmoji: r.ThumbnailEmoji,
+		Options:        r.Options,
+		CorrectIndex:   *r.CorrectIndex,
+		Explanation:    r.Explanation,
+		Difficulty:     r.Difficulty,
+		Category:       r.Category,
+		Tags:           r.Tags,
+		Status:         r.Status,
+	}, nil
+}
+
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Decode reads every row of a file. It returns an error only when the file
+// as a whole cannot be read; problems with single rows are reported in
+// their Row.
+func Decode(format string, r io.Reader) ([]Row, error) {
+	switch format {
+	case FormatJSONL:
+		return decodeJSONL(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func decodeJSONL(r io.Reader) ([]Row, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []Row
+	line := 0
+	for sc.Scan() {
+		line++
+		text := bytes.TrimSpace(sc.Bytes())
+		if line == 1 {
+			text = bytes.TrimPrefix(text, []byte("\ufeff"))
+		}
+		if len(text) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.DisallowUnknownFields()
+		var rec record
+		if err := dec.Decode(&rec); err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+		if dec.More() {
+			rows = append(rows, Row{Line: line, Err: errors.New("more than one object on the line")})
+			continue
+		}
+		q, err := rec.question()
+		rows = append(rows, Row{Line: line, Question: q, Err: err})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", line+1, err)
+	}
+	return rows, nil
+}
+
+func csvHeader() []string {
+	header := []string{"external_key", "video_url", "thumbnail_emoji", "difficulty", "category", "status"}
+	for i := 1; i <= MaxOptions; i++ {
+		header = append(header, "option_"+strconv.Itoa(i))
+	}
+	return append(header, "correct_index", "explanation", "tags")
+}
+
+func decodeCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	known := make(map[string]bool)
+	for _, name := range csvHeader() {
+		known[name] = true
+	}
+	col := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, fmt.Errorf("header: unknown column %q", name)
+		}
+		if _, dup := col[name]; dup {
+			return nil, fmt.Errorf("header: column %q appears twice", name)
+		}
+		col[name] = i
+	}
+	for _, name := range []string{"external_key", "option_1", "option_2", "correct_index"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("header: missing column %q", name)
+		}
+	}
+
+	var rows []Row
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) && errors.Is(err, csv.ErrFieldCount) {
+				rows = append(rows, Row{Line: perr.StartLine, Err: errors.New("wrong number of columns")})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if blank(fields) {
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := col[name]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		q := &pb.QuizQuestion{
+			ExternalKey:    get("external_key"),
+			VideoUrl:       get("video_url"),
+			ThumbnailEmoji: get("thumbnail_emoji"),
+			Explanation:    get("explanation"),
+			Difficulty:     get("difficulty"),
+			Category:       get("category"),
+			Status:         get("status"),
+		}
+		for i := 1; i <= MaxOptions; i++ {
+			q.Options = append(q.Options, get("option_"+strconv.Itoa(i)))
+		}
+		// Trailing empty cells are unused options; a gap before a filled one
+		// would shift correct_index, so it is left for validation to reject.
+		for len(q.Options) > 0 && q.Options[len(q.Options)-1] == "" {
+			q.Options = q.Options[:len(q.Options)-1]
+		}
+		for _, tag := range strings.Split(get("tags"), TagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				q.Tags = append(q.Tags, tag)
+			}
+		}
+		index, err := strconv.ParseInt(get("correct_index"), 10, 32)
+		if err != nil {
+			rows = append(rows, Row{Line: line, Question: q, Err: errors.New("correct_index must be a number")})
+			continue
+		}
+		q.CorrectIndex = int32(index)
+		rows = append(rows, Row{Line: line, Question: q})
+	}
+	return rows, nil
+}
+
+func blank(fields []string) bool {
+	for _, f := range fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode writes questions in format. In CSV, questions with more options
+// than MaxOptions are rejected rather than cut short.
+func Encode(format string, w io.Writer, questions []*pb.QuizQuestion) error {
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, q := range questions {
+			index := q.CorrectIndex
+			if err := enc.Encode(record{
+				ExternalKey:    q.ExternalKey,
+				VideoURL:       q.VideoUrl,
+				ThumbnailEmoji: q.ThumbnailEmoji,
+				Options:        q.Options,
+				CorrectIndex:   &index,
+				Explanation:    q.Explanation,
+				Difficulty:     q.Difficulty,
+				Category:       q.Category,
+				Tags:           q.Tags,
+				Status:         q.Status,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader()); err != nil {
+			return err
+		}
+		for _, q := range questions {
+			if len(q.Options) > MaxOptions {
+				return fmt.Errorf("question %s has more than %d options", q.Id, MaxOptions)
+			}
+			fields := []string{q.ExternalKey, q.VideoUrl, q.ThumbnailEmoji, q.Difficulty, q.Category, q.Status}
+			for i := 0; i < MaxOptions; i++ {
+				opt := ""
+				if i < len(q.Options) {
+					opt = q.Options[i]
+				}
+				fields = append(fields, opt)
+			}
+			fields = append(fields, strconv.Itoa(int(q.CorrectIndex)), q.Explanation, strings.Join(q.Tags, TagSeparator))
+			if err := cw.Write(fields); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return ErrUnknownFormat
+	}
+}
@@ -0,0 +1,66 @@
+// Package rating estimates player skill and question difficulty on a shared
+// Elo scale. Every answer is treated as a match between the player and the
+// question: a correct answer moves the player up and the question down.
+package rating
+
+import "math"
+
+// Initial is a new player's rating.
+const Initial = 1000.0
+
+// TargetSuccess is the chance of answering correctly that adaptive
+// selection aims for: hard enough to learn from, easy enough to keep going.
+const TargetSuccess = 0.7
+
+// ForDifficulty is the starting rating of a question with the author's
+// difficulty label, used until answers calibrate it.
+func ForDifficulty(difficulty string) float64 {
+	switch difficulty {
+	case "easy":
+		return 850
+	case "hard":
+		return 1150
+	default:
+		return Initial
+	}
+}
+
+// Expected is the probability that a player rated player answers a question
+// rated question correctly.
+func Expected(player, question float64) float64 {
+	return 1 / (1 + math.Pow(10, (question-player)/400))
+}
+
+// Update returns both ratings after an answer. answeredByPlayer and
+// answeredQuestion are how many rated answers each side already has; new
+// players and questions move faster so they settle quickly.
+func Update(player, question float64, correct bool, answeredByPlayer, answeredQuestion int) (newPlayer, newQuestion float64) {
+	score := 0.0
+	if correct {
+		score = 1
+	}
+	delta := score - Expected(player, question)
+	return player + playerK(answeredByPlayer)*delta, question - questionK(answeredQuestion)*delta
+}
+
+// Target is the question rating a player rated player has TargetSuccess
+// chance of answering.
+func Target(player float64) float64 {
+	return player + 400*math.Log10(1/TargetSuccess-1)
+}
+
+func playerK(answered int) float64 {
+	if answered < 30 {
+		return 40
+	}
+	return 20
+}
+
+// Questions are answered by many players, so each answer says less about
+// them and they move more slowly.
+func questionK(answered int) float64 {
+	if answered < 50 {
+		return 16
+	}
+	return 4
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrAttemptInvalid = errors.New("question attempt invalid, expired or already answered")
+
+// CreateAttempt records that questionID was served to userID and returns the
+// attempt id the answer has to quote. It expires ttl from now by the
+// database clock, which useAttempt checks it against.
+func (r *QuizRepository) CreateAttempt(ctx context.Context, userID, questionID string, ttl time.Duration) (string, error) {
+	var id string
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO quiz.question_attempts (user_id, question_id, expires_at)
+		 VALUES ($1, $2, NOW() + make_interval(secs => $3)) RETURNING id`,
+		userID, questionID, ttl.Seconds(),
+	).Scan(&id)
+	return id, err
+}
+
+// useAttempt marks the attempt answered. It fails with ErrAttemptInvalid
+// unless the attempt was served to userID for questionID, has not expired and
+// has not been answered before.
+func useAttempt(ctx context.Context, tx *sql.Tx, attemptID, userID, questionID string) error {
+	if _, err := uuid.Parse(attemptID); err != nil {
+		return ErrAttemptInvalid
+	}
+	if _, err := uuid.Parse(questionID); err != nil {
+		return ErrAttemptInvalid
+	}
+	res, err := tx.ExecContext(ctx,
+		`UPDATE quiz.question_attempts SET answered_at = NOW()
+		 WHERE id = $1 AND user_id = $2 AND question_id = $3
+		   AND answered_at IS NULL AND expires_at > NOW()`,
+		attemptID, userID, questionID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAttemptInvalid
+	}
+	return nil
+}
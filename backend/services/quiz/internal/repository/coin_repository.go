@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// The coin balance is quiz.user_stats.coins. Coins are only earned and
+// spent in quiz, so quiz owns the balance and changes it in the same
+// transaction as the answer, timed session or refill behind the change.
+// Each change queues quiz.coin_balance_changed with the new balance and its
+// version, from which auth keeps the copy the user's profile shows.
+
+// startingCoins is the balance a player has before their first change,
+// the column default of quiz.user_stats.coins.
+const startingCoins = 100
+
+// creditCoins adds coins to userID's balance.
+func creditCoins(ctx context.Context, tx *sql.Tx, userID string, coins int32) error {
+	if coins <= 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.user_stats (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`, userID); err != nil {
+		return err
+	}
+	_, err := changeCoins(ctx, tx, userID, coins)
+	return err
+}
+
+// spendCoins takes cost from userID's balance and returns what is left. It
+// fails with ErrNotEnoughCoins when the balance is short. The caller has
+// created the user's stats row.
+func spendCoins(ctx context.Context, tx *sql.Tx, userID string, cost int32) (int32, error) {
+	left, err := changeCoins(ctx, tx, userID, -cost)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotEnoughCoins
+	}
+	return left, err
+}
+
+// changeCoins adds delta to the balance unless that would take it below
+// zero, in which case it returns sql.ErrNoRows, and queues the change.
+func changeCoins(ctx context.Context, tx *sql.Tx, userID string, delta int32) (int32, error) {
+	var coins int32
+	var version int64
+	if err := tx.QueryRowContext(ctx,
+		`UPDATE quiz.user_stats SET coins = coins + $2, coins_version = coins_version + 1, updated_at = NOW()
+		 WHERE user_id = $1 AND coins + $2 >= 0
+		 RETURNING coins, coins_version`, userID, delta,
+	).Scan(&coins, &version); err != nil {
+		return 0, err
+	}
+	return coins, queueEvent(ctx, tx, "quiz.coin_balance_changed", map[string]interface{}{
+		"user_id": userID,
+		"coins":   coins,
+		"version": version,
+		"change":  delta,
+	})
+}
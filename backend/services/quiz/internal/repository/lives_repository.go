@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+)
+
+var (
+	ErrNoLivesLeft    = errors.New("no lives left")
+	ErrLivesFull      = errors.New("lives already full")
+	ErrNotEnoughCoins = errors.New("not enough coins")
+)
+
+// NoLivesLeftError is returned by SubmitAnswer at zero lives.
+type NoLivesLeftError struct {
+	RetryAfter time.Duration
+}
+
+func (e *NoLivesLeftError) Error() string {
+	return fmt.Sprintf("%v, next life in %s", ErrNoLivesLeft, e.RetryAfter)
+}
+
+func (e *NoLivesLeftError) Unwrap() error {
+	return ErrNoLivesLeft
+}
+
+// RefillLives tops the user's lives up to policy.Max for costPerLife coins
+// each, paid from the user's coin balance. It returns the coins spent and
+// the balance left.
+func (r *QuizRepository) RefillLives(ctx context.Context, userID string, policy lives.Policy, costPerLife int32) (spent, left int32, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.user_stats (user_id, lives) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`,
+		userID, policy.Max); err != nil {
+		return 0, 0, err
+	}
+	var n int32
+	var since time.Time
+	if err := tx.QueryRowContext(ctx,
+		`SELECT lives, lives_updated_at FROM quiz.user_stats WHERE user_id = $1 FOR UPDATE`, userID,
+	).Scan(&n, &since); err != nil {
+		return 0, 0, err
+	}
+	n, _ = policy.Regenerate(n, since, time.Now().UTC())
+	if n >= policy.Max {
+		return 0, 0, ErrLivesFull
+	}
+
+	cost := (policy.Max - n) * costPerLife
+	left, err = spendCoins(ctx, tx, userID, cost)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE quiz.user_stats SET lives = $2, lives_updated_at = $3 WHERE user_id = $1`,
+		userID, policy.Max, time.Now().UTC()); err != nil {
+		return 0, 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return cost, left, nil
+}
+
+// spendLife takes the life a timed session costs to start, once lives
+// regenerated under policy are added back. At zero lives it fails with a
+// *NoLivesLeftError.
+func spendLife(ctx context.Context, tx *sql.Tx, userID string, policy lives.Policy) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.user_stats (user_id, lives) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`,
+		userID, policy.Max); err != nil {
+		return err
+	}
+	var n int32
+	var since time.Time
+	if err := tx.QueryRowContext(ctx,
+		`SELECT lives, lives_updated_at FROM quiz.user_stats WHERE user_id = $1 FOR UPDATE`, userID,
+	).Scan(&n, &since); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	n, since = policy.Regenerate(n, since, now)
+	if n <= 0 {
+		return &NoLivesLeftError{RetryAfter: policy.NextIn(n, since, now)}
+	}
+	_, err := tx.ExecContext(ctx,
+		`UPDATE quiz.user_stats SET lives = $2, lives_updated_at = $3 WHERE user_id = $1`,
+		userID, n-1, since)
+	return err
+}
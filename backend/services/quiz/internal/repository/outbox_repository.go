@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the
+// change it reports and published afterwards, so the change cannot happen
+// without the event eventually going out.
+type OutboxEvent struct {
+	ID        int64
+	Type      string
+	Payload   map[string]interface{}
+	CreatedAt time.Time
+}
+
+// PendingEvents returns up to limit unpublished events, oldest first.
+func (r *QuizRepository) PendingEvents(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, event_type, payload, created_at FROM quiz.event_outbox
+		 WHERE published_at IS NULL ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Type, &payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &e.Payload); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+func (r *QuizRepository) MarkEventPublished(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE quiz.event_outbox SET published_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// queueEvent writes an event to the outbox as part of tx.
+func queueEvent(ctx context.Context, tx *sql.Tx, eventType string, payload map[string]interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO quiz.event_outbox (event_type, payload) VALUES ($1, $2)`, eventType, data)
+	return err
+}
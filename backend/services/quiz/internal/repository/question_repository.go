@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/pawfiler/backend/services/quiz/internal/rating"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+var (
+	ErrQuestionArchived = errors.New("question archived")
+	ErrExternalKeyTaken = errors.New("external key already used by another question")
+)
+
+// Question lifecycle. Questions start as drafts, only published ones are
+// served to players, and archived ones are kept for answer history but can
+// no longer be edited.
+const (
+	QuestionStatusDraft     = "draft"
+	QuestionStatusPublished = "published"
+	QuestionStatusArchived  = "archived"
+)
+
+const questionColumns = `id, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty,
+	tags, status, COALESCE(created_by::text, ''), created_at, updated_at, COALESCE(external_key, ''),
+	rating, rated_answers, category`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanQuestion(row scanner) (*pb.QuizQuestion, error) {
+	var q pb.QuizQuestion
+	var options, tags pq.StringArray
+	var createdAt, updatedAt time.Time
+	err := row.Scan(
+		&q.Id, &q.VideoUrl, &q.ThumbnailEmoji, &options, &q.CorrectIndex, &q.Explanation, &q.Difficulty,
+		&tags, &q.Status, &q.CreatedBy, &createdAt, &updatedAt, &q.ExternalKey,
+		&q.Rating, &q.RatedAnswers, &q.Category,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q.Options = options
+	q.Tags = tags
+	q.CreatedAt = createdAt.Format(time.RFC3339)
+	q.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return &q, nil
+}
+
+// CreateQuestion stores q as a draft written by authorID. Without an
+// external key it gets its own id as one.
+func (r *QuizRepository) CreateQuestion(ctx context.Context, q *pb.QuizQuestion, authorID string) (*pb.QuizQuestion, error) {
+	id, key := uuid.NewString(), q.ExternalKey
+	if key == "" {
+		key = id
+	}
+	created, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`INSERT INTO quiz.questions (id, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty, tags, status, created_by, external_key, rating, category)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		 RETURNING `+questionColumns,
+		id, q.VideoUrl, q.ThumbnailEmoji, pq.StringArray(q.Options), q.CorrectIndex, q.Explanation, q.Difficulty,
+		pq.StringArray(q.Tags), QuestionStatusDraft, authorID, key, rating.ForDifficulty(q.Difficulty), q.Category,
+	))
+	if isUniqueViolation(err) {
+		return nil, ErrExternalKeyTaken
+	}
+	return created, err
+}
+
+// UpdateQuestion replaces the content and status of q.Id. Archived questions
+// cannot be updated. The rating follows a changed difficulty only until the
+// question has been answered.
+func (r *QuizRepository) UpdateQuestion(ctx context.Context, q *pb.QuizQuestion) (*pb.QuizQuestion, error) {
+	if _, err := uuid.Parse(q.Id); err != nil {
+		return nil, ErrQuestionNotFound
+	}
+	updated, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`UPDATE quiz.questions SET video_url = $2, thumbnail_emoji = $3, options = $4, correct_index = $5,
+		        explanation = $6, difficulty = $7, tags = $8, status = $9,
+		        external_key = COALESCE(NULLIF($10, ''), external_key),
+		        rating = CASE WHEN rated_answers = 0 THEN $11 ELSE rating END, category = $12, updated_at = NOW()
+		 WHERE id = $1 AND status <> 'archived'
+		 RETURNING `+questionColumns,
+		q.Id, q.VideoUrl, q.ThumbnailEmoji, pq.StringArray(q.Options), q.CorrectIndex, q.Explanation, q.Difficulty,
+		pq.StringArray(q.Tags), q.Status, q.ExternalKey, rating.ForDifficulty(q.Difficulty), q.Category,
+	))
+	if err == sql.ErrNoRows {
+		return nil, r.missingOrArchived(ctx, q.Id)
+	}
+	if isUniqueViolation(err) {
+		return nil, ErrExternalKeyTaken
+	}
+	return updated, err
+}
+
+// ArchiveQuestion takes the question out of play. Archiving twice is not an
+// error.
+func (r *QuizRepository) ArchiveQuestion(ctx context.Context, questionID string) (*pb.QuizQuestion, error) {
+	if _, err := uuid.Parse(questionID); err != nil {
+		return nil, ErrQuestionNotFound
+	}
+	q, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`UPDATE quiz.questions
+		 SET status = 'archived', updated_at = CASE WHEN status = 'archived' THEN updated_at ELSE NOW() END
+		 WHERE id = $1
+		 RETURNING `+questionColumns,
+		questionID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, ErrQuestionNotFound
+	}
+	return q, err
+}
+
+func (r *QuizRepository) missingOrArchived(ctx context.Context, questionID string) error {
+	var status string
+	err := r.db.QueryRowContext(ctx, `SELECT status FROM quiz.questions WHERE id = $1`, questionID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrQuestionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return ErrQuestionArchived
+}
+
+// QuestionFilter narrows ListQuestions. Nil fields match everything, and a
+// zero Limit returns every match.
+type QuestionFilter struct {
+	Difficulty *string
+	Tag        *string
+	Status     *string
+	Limit      int
+	Offset     int
+}
+
+// ListQuestions returns a page of questions, newest first, and how many
+// match the filter in total.
+func (r *QuizRepository) ListQuestions(ctx context.Context, f QuestionFilter) ([]*pb.QuizQuestion, int32, error) {
+	var conds []string
+	var args []interface{}
+	if f.Difficulty != nil {
+		args = append(args, *f.Difficulty)
+		conds = append(conds, fmt.Sprintf("difficulty = $%d", len(args)))
+	}
+	if f.Tag != nil {
+		args = append(args, *f.Tag)
+		conds = append(conds, fmt.Sprintf("$%d = ANY(tags)", len(args)))
+	}
+	if f.Status != nil {
+		args = append(args, *f.Status)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM quiz.questions`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + questionColumns + ` FROM quiz.questions` + where + ` ORDER BY created_at DESC, id`
+	if f.Limit > 0 {
+		args = append(args, f.Limit, f.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	questions := []*pb.QuizQuestion{}
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, total, rows.Err()
+}
+
+// StatusesByExternalKey returns the status of each existing question whose
+// external key is in keys.
+func (r *QuizRepository) StatusesByExternalKey(ctx context.Context, keys []string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT external_key, status FROM quiz.questions WHERE external_key = ANY($1)`, pq.StringArray(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]string)
+	for rows.Next() {
+		var key, status string
+		if err := rows.Scan(&key, &status); err != nil {
+			return nil, err
+		}
+		statuses[key] = status
+	}
+	return statuses, rows.Err()
+}
+
+// UpsertQuestions creates or replaces questions by external key in one
+// transaction, recording authorID as the author of new ones. If an existing
+// question turns out to be archived nothing is written and
+// ErrQuestionArchived is returned.
+func (r *QuizRepository) UpsertQuestions(ctx context.Context, questions []*pb.QuizQuestion, authorID string) (created, updated int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO quiz.questions (external_key, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty, tags, status, created_by, rating, category)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 ON CONFLICT (external_key) DO UPDATE SET
+		   video_url = EXCLUDED.video_url, thumbnail_emoji = EXCLUDED.thumbnail_emoji, options = EXCLUDED.options,
+		   correct_index = EXCLUDED.correct_index, explanation = EXCLUDED.explanation, difficulty = EXCLUDED.difficulty,
+		   tags = EXCLUDED.tags, status = EXCLUDED.status, category = EXCLUDED.category,
+		   rating = CASE WHEN quiz.questions.rated_answers = 0 THEN EXCLUDED.rating ELSE quiz.questions.rating END,
+		   updated_at = NOW()
+		 WHERE quiz.questions.status <> 'archived'
+		 RETURNING xmax = 0`)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer stmt.Close()
+
+	for _, q := range questions {
+		var inserted bool
+		err := stmt.QueryRowContext(ctx,
+			q.ExternalKey, q.VideoUrl, q.ThumbnailEmoji, pq.StringArray(q.Options), q.CorrectIndex, q.Explanation,
+			q.Difficulty, pq.StringArray(q.Tags), q.Status, authorID, rating.ForDifficulty(q.Difficulty), q.Category,
+		).Scan(&inserted)
+		if err == sql.ErrNoRows {
+			return 0, 0, fmt.Errorf("%w: %s", ErrQuestionArchived, q.ExternalKey)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if inserted {
+			created++
+		} else {
+			updated++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -3,12 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	"github.com/pawfiler/backend/services/quiz/internal/rating"
 	pb "github.com/pawfiler/backend/services/quiz/pb"
 )
 
+var ErrQuestionNotFound = errors.New("question not found")
+
 type QuizRepository struct {
 	db *sql.DB
 }
@@ -17,83 +25,132 @@ func NewQuizRepository(db *sql.DB) *QuizRepository {
 	return &QuizRepository{db: db}
 }
 
-func (r *QuizRepository) GetRandomQuestion(ctx context.Context, difficulty *string) (*pb.QuizQuestion, error) {
-	query := `SELECT id, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty 
-	          FROM quiz.questions`
-	
-	if difficulty != nil && *difficulty != "" {
-		query += ` WHERE difficulty = $1`
+// QuestionSelection says which published question GetRandomQuestion should
+// pick. A pinned Difficulty wins over TargetRating.
+type QuestionSelection struct {
+	Difficulty   *string
+	TargetRating *float64
+	// Exclude lists question IDs not to serve.
+	Exclude []string
+}
+
+// ratingSpread is how far, in rating points, picks wander from the target
+// so the same question does not come up every time.
+const ratingSpread = 150
+
+// GetRandomQuestion picks a published question: at random within a pinned
+// difficulty, otherwise near the target rating when there is one.
+//
+// Instead of sorting the whole pool it draws a pivot and takes the question
+// nearest to it on an indexed column, random_key for uniform picks and
+// rating for targeted ones, so the cost does not grow with the pool.
+func (r *QuizRepository) GetRandomQuestion(ctx context.Context, sel QuestionSelection) (*pb.QuizQuestion, error) {
+	where := `status = 'published' AND id <> ALL($1::uuid[])`
+	exclude := sel.Exclude
+	if exclude == nil {
+		exclude = []string{}
 	}
-	query += ` ORDER BY RANDOM() LIMIT 1`
+	args := []interface{}{pq.Array(exclude)}
 
-	var q pb.QuizQuestion
-	var options pq.StringArray
+	column, pivot := "random_key", rand.Float64()
+	switch {
+	case sel.Difficulty != nil && *sel.Difficulty != "":
+		args = append(args, *sel.Difficulty)
+		where += ` AND difficulty = $2`
+	case sel.TargetRating != nil:
+		column, pivot = "rating", *sel.TargetRating+(rand.Float64()*2-1)*ratingSpread
+	}
+	args = append(args, pivot)
+	p := fmt.Sprintf("$%d", len(args))
 
-	var err error
-	if difficulty != nil && *difficulty != "" {
-		err = r.db.QueryRowContext(ctx, query, *difficulty).Scan(
-			&q.Id, &q.VideoUrl, &q.ThumbnailEmoji, &options, &q.CorrectIndex, &q.Explanation, &q.Difficulty,
-		)
-	} else {
-		err = r.db.QueryRowContext(ctx, query).Scan(
-			&q.Id, &q.VideoUrl, &q.ThumbnailEmoji, &options, &q.CorrectIndex, &q.Explanation, &q.Difficulty,
-		)
+	query := `SELECT ` + questionColumns + ` FROM quiz.questions WHERE id = (
+		SELECT id FROM (
+			(SELECT id, ` + column + ` - ` + p + ` AS distance FROM quiz.questions
+			 WHERE ` + where + ` AND ` + column + ` >= ` + p + ` ORDER BY ` + column + ` LIMIT 1)
+			UNION ALL
+			(SELECT id, ` + p + ` - ` + column + ` FROM quiz.questions
+			 WHERE ` + where + ` AND ` + column + ` < ` + p + ` ORDER BY ` + column + ` DESC LIMIT 1)
+		) AS nearest ORDER BY distance LIMIT 1)`
+
+	q, err := scanQuestion(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrQuestionNotFound
 	}
+	return q, err
+}
 
+// RecentQuestionIDs returns the questions of the user's last n answers,
+// newest first.
+func (r *QuizRepository) RecentQuestionIDs(ctx context.Context, userID string, n int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT question_id FROM quiz.user_answers WHERE user_id = $1 ORDER BY answered_at DESC LIMIT $2`,
+		userID, n)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	q.Options = options
-	return &q, nil
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
 }
 
+// GetQuestionById returns the question whatever its status.
 func (r *QuizRepository) GetQuestionById(ctx context.Context, questionID string) (*pb.QuizQuestion, error) {
-	query := `SELECT id, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty 
-	          FROM quiz.questions WHERE id = $1`
-
-	var q pb.QuizQuestion
-	var options pq.StringArray
-
-	err := r.db.QueryRowContext(ctx, query, questionID).Scan(
-		&q.Id, &q.VideoUrl, &q.ThumbnailEmoji, &options, &q.CorrectIndex, &q.Explanation, &q.Difficulty,
-	)
-	if err != nil {
-		return nil, err
+	if _, err := uuid.Parse(questionID); err != nil {
+		return nil, ErrQuestionNotFound
 	}
-
-	q.Options = options
-	return &q, nil
+	q, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM quiz.questions WHERE id = $1`, questionID))
+	if err == sql.ErrNoRows {
+		return nil, ErrQuestionNotFound
+	}
+	return q, err
 }
 
-func (r *QuizRepository) SaveAnswer(ctx context.Context, userID, questionID string, selectedIndex int32, correct bool, xp, coins int32) error {
-	query := `INSERT INTO quiz.user_answers (user_id, question_id, selected_index, is_correct, xp_earned, coins_earned)
-	          VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.ExecContext(ctx, query, userID, questionID, selectedIndex, correct, xp, coins)
+// saveAnswer records an answer to q, keeping the question's difficulty and
+// category as they are now for the stats breakdowns.
+func saveAnswer(ctx context.Context, tx *sql.Tx, userID string, q *pb.QuizQuestion, selectedIndex int32, correct bool, xp, coins int32) error {
+	query := `INSERT INTO quiz.user_answers (user_id, question_id, selected_index, is_correct, xp_earned, coins_earned, difficulty, category)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := tx.ExecContext(ctx, query, userID, q.Id, selectedIndex, correct, xp, coins, q.Difficulty, q.Category)
 	return err
 }
 
-func (r *QuizRepository) UpdateStats(ctx context.Context, userID string, correct bool) (*pb.QuizStats, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
+// updateStats counts an answer. Lives regenerated under policy are added
+// back first; at zero lives it fails with a *NoLivesLeftError, and a wrong
+// answer costs a life.
+func updateStats(ctx context.Context, tx *sql.Tx, userID string, correct bool, policy lives.Policy) (*pb.QuizStats, error) {
+	// Create the row first so the lock below has something to hold even on
+	// a player's first answer.
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.user_stats (user_id, lives) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`,
+		userID, policy.Max); err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
 	var stats pb.QuizStats
-	query := `SELECT total_answered, correct_count, current_streak, best_streak, lives 
+	var livesSince time.Time
+	now := time.Now().UTC()
+	query := `SELECT total_answered, correct_count, current_streak, best_streak, lives, lives_updated_at
 	          FROM quiz.user_stats WHERE user_id = $1 FOR UPDATE`
-	
-	err = tx.QueryRowContext(ctx, query, userID).Scan(
-		&stats.TotalAnswered, &stats.CorrectRate, &stats.CurrentStreak, &stats.BestStreak, &stats.Lives,
-	)
 
-	if err == sql.ErrNoRows {
-		stats = pb.QuizStats{TotalAnswered: 0, CorrectRate: 0, CurrentStreak: 0, BestStreak: 0, Lives: 3}
-	} else if err != nil {
+	if err := tx.QueryRowContext(ctx, query, userID).Scan(
+		&stats.TotalAnswered, &stats.CorrectCount, &stats.CurrentStreak, &stats.BestStreak, &stats.Lives, &livesSince,
+	); err != nil {
 		return nil, err
 	}
 
+	stats.Lives, livesSince = policy.Regenerate(stats.Lives, livesSince, now)
+	if stats.Lives <= 0 {
+		return nil, &NoLivesLeftError{RetryAfter: policy.NextIn(stats.Lives, livesSince, now)}
+	}
 	stats.TotalAnswered++
 	if correct {
 		stats.CurrentStreak++
@@ -104,51 +161,191 @@ func (r *QuizRepository) UpdateStats(ctx context.Context, userID string, correct
 		stats.CurrentStreak = 0
 		stats.Lives--
 	}
+	stats.MaxLives = policy.Max
+	stats.NextLifeIn = int64(policy.NextIn(stats.Lives, livesSince, now).Seconds())
 
-	correctCount := int32(float64(stats.TotalAnswered) * stats.CorrectRate)
 	if correct {
-		correctCount++
+		stats.CorrectCount++
 	}
-	stats.CorrectRate = float64(correctCount) / float64(stats.TotalAnswered)
+	stats.CorrectRate = float64(stats.CorrectCount) / float64(stats.TotalAnswered)
 
-	upsertQuery := `INSERT INTO quiz.user_stats (user_id, total_answered, correct_count, current_streak, best_streak, lives)
-	                VALUES ($1, $2, $3, $4, $5, $6)
-	                ON CONFLICT (user_id) DO UPDATE SET
-	                total_answered = $2, correct_count = $3, current_streak = $4, best_streak = $5, lives = $6`
-	
-	_, err = tx.ExecContext(ctx, upsertQuery, userID, stats.TotalAnswered, correctCount, stats.CurrentStreak, stats.BestStreak, stats.Lives)
-	if err != nil {
-		return nil, err
-	}
+	updateQuery := `UPDATE quiz.user_stats
+	                SET total_answered = $2, correct_count = $3, current_streak = $4, best_streak = $5, lives = $6, lives_updated_at = $7
+	                WHERE user_id = $1`
 
-	if err = tx.Commit(); err != nil {
+	if _, err := tx.ExecContext(ctx, updateQuery, userID, stats.TotalAnswered, stats.CorrectCount, stats.CurrentStreak, stats.BestStreak, stats.Lives, livesSince); err != nil {
 		return nil, err
 	}
-
 	return &stats, nil
 }
 
-func (r *QuizRepository) GetUserStats(ctx context.Context, userID string) (*pb.QuizStats, error) {
-	query := `SELECT total_answered, correct_count, current_streak, best_streak, lives 
+func (r *QuizRepository) GetUserStats(ctx context.Context, userID string, policy lives.Policy) (*pb.QuizStats, error) {
+	query := `SELECT total_answered, correct_count, current_streak, best_streak, lives, lives_updated_at, rating, coins
 	          FROM quiz.user_stats WHERE user_id = $1`
 
 	var stats pb.QuizStats
-	var correctCount int32
+	var livesSince time.Time
 
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&stats.TotalAnswered, &correctCount, &stats.CurrentStreak, &stats.BestStreak, &stats.Lives,
+		&stats.TotalAnswered, &stats.CorrectCount, &stats.CurrentStreak, &stats.BestStreak, &stats.Lives, &livesSince, &stats.Rating, &stats.Coins,
 	)
 
 	if err == sql.ErrNoRows {
-		return &pb.QuizStats{TotalAnswered: 0, CorrectRate: 0, CurrentStreak: 0, BestStreak: 0, Lives: 3}, nil
+		return &pb.QuizStats{TotalAnswered: 0, CorrectRate: 0, CurrentStreak: 0, BestStreak: 0, Lives: policy.Max, Rating: rating.Initial, MaxLives: policy.Max, Coins: startingCoins}, nil
 	}
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now().UTC()
+	stats.Lives, livesSince = policy.Regenerate(stats.Lives, livesSince, now)
+	stats.MaxLives = policy.Max
+	stats.NextLifeIn = int64(policy.NextIn(stats.Lives, livesSince, now).Seconds())
+
 	if stats.TotalAnswered > 0 {
-		stats.CorrectRate = float64(correctCount) / float64(stats.TotalAnswered)
+		stats.CorrectRate = float64(stats.CorrectCount) / float64(stats.TotalAnswered)
 	}
 
 	return &stats, nil
 }
+
+type UserAnswer struct {
+	QuestionID    string    `json:"question_id"`
+	Difficulty    string    `json:"difficulty"`
+	Category      string    `json:"category"`
+	SelectedIndex int32     `json:"selected_index"`
+	IsCorrect     bool      `json:"is_correct"`
+	XPEarned      int32     `json:"xp_earned"`
+	CoinsEarned   int32     `json:"coins_earned"`
+	AnsweredAt    time.Time `json:"answered_at"`
+}
+
+type UserStatsRecord struct {
+	TotalAnswered int32     `json:"total_answered"`
+	CorrectCount  int32     `json:"correct_count"`
+	CurrentStreak int32     `json:"current_streak"`
+	BestStreak    int32     `json:"best_streak"`
+	Lives         int32     `json:"lives"`
+	Rating        float64   `json:"rating"`
+	Coins         int32     `json:"coins"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ReviewRecord struct {
+	QuestionID string    `json:"question_id"`
+	Box        int32     `json:"box"`
+	DueAt      time.Time `json:"due_at"`
+	Lapses     int32     `json:"lapses"`
+}
+
+// UserData is everything the quiz schema stores about one user.
+type UserData struct {
+	Stats         *UserStatsRecord     `json:"stats"`
+	Answers       []UserAnswer         `json:"answers"`
+	Reviews       []ReviewRecord       `json:"reviews"`
+	TimedSessions []TimedSessionRecord `json:"timed_sessions"`
+}
+
+func (r *QuizRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	data := &UserData{Answers: []UserAnswer{}, Reviews: []ReviewRecord{}, TimedSessions: []TimedSessionRecord{}}
+
+	var stats UserStatsRecord
+	err := r.db.QueryRowContext(ctx,
+		`SELECT total_answered, correct_count, current_streak, best_streak, lives, rating, coins, updated_at
+		 FROM quiz.user_stats WHERE user_id = $1`, userID,
+	).Scan(&stats.TotalAnswered, &stats.CorrectCount, &stats.CurrentStreak, &stats.BestStreak, &stats.Lives, &stats.Rating, &stats.Coins, &stats.UpdatedAt)
+	if err == nil {
+		data.Stats = &stats
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT question_id, difficulty, category, selected_index, is_correct, xp_earned, coins_earned, answered_at
+		 FROM quiz.user_answers WHERE user_id = $1 ORDER BY answered_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a UserAnswer
+		if err := rows.Scan(&a.QuestionID, &a.Difficulty, &a.Category, &a.SelectedIndex, &a.IsCorrect, &a.XPEarned, &a.CoinsEarned, &a.AnsweredAt); err != nil {
+			return nil, err
+		}
+		data.Answers = append(data.Answers, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	reviews, err := r.db.QueryContext(ctx,
+		`SELECT question_id, box, due_at, lapses
+		 FROM quiz.review_schedule WHERE user_id = $1 ORDER BY due_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer reviews.Close()
+
+	for reviews.Next() {
+		var rv ReviewRecord
+		if err := reviews.Scan(&rv.QuestionID, &rv.Box, &rv.DueAt, &rv.Lapses); err != nil {
+			return nil, err
+		}
+		data.Reviews = append(data.Reviews, rv)
+	}
+	if err := reviews.Err(); err != nil {
+		return nil, err
+	}
+
+	sessions, err := r.db.QueryContext(ctx,
+		`SELECT `+timedSessionColumns+` FROM quiz.timed_sessions WHERE user_id = $1 ORDER BY started_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer sessions.Close()
+
+	for sessions.Next() {
+		s, err := scanTimedSession(sessions)
+		if err != nil {
+			return nil, err
+		}
+		data.TimedSessions = append(data.TimedSessions, *s)
+	}
+	return data, sessions.Err()
+}
+
+// DeleteUserData erases the user's answers, reviews, timed sessions and
+// stats. It is safe to repeat.
+func (r *QuizRepository) DeleteUserData(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.question_attempts WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.answer_submissions WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.review_schedule WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM quiz.timed_session_answers
+		 WHERE session_id IN (SELECT id FROM quiz.timed_sessions WHERE user_id = $1)`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.timed_sessions WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.user_answers WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz.user_stats WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
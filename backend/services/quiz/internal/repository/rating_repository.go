@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pawfiler/backend/services/quiz/internal/rating"
+)
+
+// GetUserRating returns the player's skill rating, or rating.Initial before
+// their first answer.
+func (r *QuizRepository) GetUserRating(ctx context.Context, userID string) (float64, error) {
+	var rt float64
+	err := r.db.QueryRowContext(ctx, `SELECT rating FROM quiz.user_stats WHERE user_id = $1`, userID).Scan(&rt)
+	if err == sql.ErrNoRows {
+		return rating.Initial, nil
+	}
+	return rt, err
+}
+
+// applyAnswerRating moves the player's and the question's ratings after an
+// answer and returns the player's new rating. Only the player's row is
+// locked: a popular question is answered by many players at once, so its
+// rating is read without a lock and moved by this answer's delta in one
+// atomic update, and concurrent answers add up instead of queueing on it.
+func applyAnswerRating(ctx context.Context, tx *sql.Tx, userID, questionID string, correct bool) (float64, error) {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.user_stats (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`, userID); err != nil {
+		return 0, err
+	}
+	var playerRating, questionRating float64
+	var playerAnswers, questionAnswers int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT rating, rated_answers FROM quiz.user_stats WHERE user_id = $1 FOR UPDATE`, userID,
+	).Scan(&playerRating, &playerAnswers); err != nil {
+		return 0, err
+	}
+	err := tx.QueryRowContext(ctx,
+		`SELECT rating, rated_answers FROM quiz.questions WHERE id = $1`, questionID,
+	).Scan(&questionRating, &questionAnswers)
+	if err == sql.ErrNoRows {
+		return 0, ErrQuestionNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	newPlayerRating, newQuestionRating := rating.Update(playerRating, questionRating, correct, playerAnswers, questionAnswers)
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE quiz.user_stats SET rating = $2, rated_answers = rated_answers + 1 WHERE user_id = $1`,
+		userID, newPlayerRating); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE quiz.questions SET rating = rating + $2, rated_answers = rated_answers + 1 WHERE id = $1`,
+		questionID, newQuestionRating-questionRating); err != nil {
+		return 0, err
+	}
+	return newPlayerRating, nil
+}
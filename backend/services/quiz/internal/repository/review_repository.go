@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/pawfiler/backend/services/quiz/internal/review"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+var ErrNoReviewsDue = errors.New("no reviews due")
+
+// updateReviewSchedule moves the user's review of questionID along after an
+// answer. A wrong answer (re)schedules the question; a correct one advances
+// it only when its review was due, so seeing it early elsewhere does not
+// count as a review.
+func updateReviewSchedule(ctx context.Context, tx *sql.Tx, userID, questionID string, correct bool) error {
+	var box int
+	var due bool
+	err := tx.QueryRowContext(ctx,
+		`SELECT box, due_at <= NOW() FROM quiz.review_schedule WHERE user_id = $1 AND question_id = $2 FOR UPDATE`,
+		userID, questionID,
+	).Scan(&box, &due)
+	switch {
+	case err == sql.ErrNoRows:
+		if correct {
+			return nil
+		}
+	case err != nil:
+		return err
+	case correct && !due:
+		return nil
+	}
+
+	next, wait, done := review.Next(box, correct)
+	lapse := 0
+	if !correct {
+		lapse = 1
+	}
+	if done {
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM quiz.review_schedule WHERE user_id = $1 AND question_id = $2`, userID, questionID)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO quiz.review_schedule (user_id, question_id, box, due_at, lapses)
+			 VALUES ($1, $2, $3, NOW() + make_interval(secs => $4), $5)
+			 ON CONFLICT (user_id, question_id) DO UPDATE SET
+			   box = EXCLUDED.box, due_at = EXCLUDED.due_at,
+			   lapses = quiz.review_schedule.lapses + EXCLUDED.lapses, updated_at = NOW()`,
+			userID, questionID, next, wait.Seconds(), lapse)
+	}
+	return err
+}
+
+// GetDueReviewQuestion returns the user's most overdue review question that
+// is still published, or ErrNoReviewsDue.
+func (r *QuizRepository) GetDueReviewQuestion(ctx context.Context, userID string) (*pb.QuizQuestion, error) {
+	q, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM quiz.questions WHERE id = (
+			SELECT s.question_id FROM quiz.review_schedule s
+			JOIN quiz.questions q ON q.id = s.question_id
+			WHERE s.user_id = $1 AND s.due_at <= NOW() AND q.status = 'published'
+			ORDER BY s.due_at LIMIT 1)`, userID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNoReviewsDue
+	}
+	return q, err
+}
+
+// CountDueReviews counts the user's reviews that GetDueReviewQuestion can
+// serve now.
+func (r *QuizRepository) CountDueReviews(ctx context.Context, userID string) (int32, error) {
+	var n int32
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM quiz.review_schedule s
+		 JOIN quiz.questions q ON q.id = s.question_id
+		 WHERE s.user_id = $1 AND s.due_at <= NOW() AND q.status = 'published'`, userID,
+	).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+// BreakdownByDifficulty counts the user's answers per question difficulty.
+func (r *QuizRepository) BreakdownByDifficulty(ctx context.Context, userID string) ([]*pb.StatsBreakdown, error) {
+	return r.breakdown(ctx, userID, "difficulty")
+}
+
+// BreakdownByCategory counts the user's answers per question category.
+func (r *QuizRepository) BreakdownByCategory(ctx context.Context, userID string) ([]*pb.StatsBreakdown, error) {
+	return r.breakdown(ctx, userID, "category")
+}
+
+// breakdown groups the user's answers by column, one of the dimensions
+// SaveAnswer keeps with each answer.
+func (r *QuizRepository) breakdown(ctx context.Context, userID, column string) ([]*pb.StatsBreakdown, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+column+`, COUNT(*), COUNT(*) FILTER (WHERE is_correct)
+		 FROM quiz.user_answers WHERE user_id = $1
+		 GROUP BY `+column+` ORDER BY `+column, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := []*pb.StatsBreakdown{}
+	for rows.Next() {
+		var b pb.StatsBreakdown
+		if err := rows.Scan(&b.Key, &b.Answered, &b.Correct); err != nil {
+			return nil, err
+		}
+		b.CorrectRate = float64(b.Correct) / float64(b.Answered)
+		breakdown = append(breakdown, &b)
+	}
+	return breakdown, rows.Err()
+}
+
+// DailyAnswers counts the user's answers on each of the last days days,
+// today included, oldest first.
+func (r *QuizRepository) DailyAnswers(ctx context.Context, userID string, days int) ([]*pb.DailyStats, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT day, COUNT(a.id), COUNT(a.id) FILTER (WHERE a.is_correct)
+		 FROM generate_series(CURRENT_DATE - ($2::int - 1), CURRENT_DATE, INTERVAL '1 day') AS day
+		 LEFT JOIN quiz.user_answers a
+		   ON a.user_id = $1 AND a.answered_at >= day AND a.answered_at < day + INTERVAL '1 day'
+		 GROUP BY day ORDER BY day`, userID, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	daily := []*pb.DailyStats{}
+	for rows.Next() {
+		var d pb.DailyStats
+		var day time.Time
+		if err := rows.Scan(&day, &d.Answered, &d.Correct); err != nil {
+			return nil, err
+		}
+		d.Date = day.Format("2006-01-02")
+		daily = append(daily, &d)
+	}
+	return daily, rows.Err()
+}
+
+// AnswerHistory returns a page of the user's answers, newest first, and
+// how many there are in total.
+func (r *QuizRepository) AnswerHistory(ctx context.Context, userID string, limit, offset int) ([]*pb.AnswerRecord, int32, error) {
+	var total int32
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM quiz.user_answers WHERE user_id = $1`, userID,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT a.question_id, q.thumbnail_emoji, a.difficulty, a.category, a.selected_index, q.correct_index,
+		        a.is_correct, a.xp_earned, a.coins_earned, a.answered_at
+		 FROM quiz.user_answers a JOIN quiz.questions q ON q.id = a.question_id
+		 WHERE a.user_id = $1
+		 ORDER BY a.answered_at DESC, a.id
+		 LIMIT $2 OFFSET $3`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	answers := []*pb.AnswerRecord{}
+	for rows.Next() {
+		var a pb.AnswerRecord
+		var answeredAt time.Time
+		if err := rows.Scan(&a.QuestionId, &a.ThumbnailEmoji, &a.Difficulty, &a.Category, &a.SelectedIndex,
+			&a.CorrectIndex, &a.Correct, &a.XpEarned, &a.CoinsEarned, &answeredAt); err != nil {
+			return nil, 0, err
+		}
+		a.AnsweredAt = answeredAt.Format(time.RFC3339)
+		answers = append(answers, &a)
+	}
+	return answers, total, rows.Err()
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used for another question")
+
+// AnswerSubmission is one answer as sent to SubmitAnswer.
+type AnswerSubmission struct {
+	UserID         string
+	QuestionID     string
+	AttemptID      string
+	IdempotencyKey string
+	SelectedIndex  int32
+	Lives          lives.Policy
+}
+
+// AnswerScore is what an answer earns.
+type AnswerScore struct {
+	Correct bool
+	XP      int32
+	Coins   int32
+}
+
+// SubmitAnswer records an answer and everything it changes, the attempt,
+// the answer itself, stats, the coin balance, ratings and the review
+// schedule, in one transaction, scoring it with score. The response is stored under the
+// submission's idempotency key: submitting the same key again returns it
+// with replayed set and changes nothing. A concurrent submit with the same
+// key waits for the first to finish.
+func (r *QuizRepository) SubmitAnswer(ctx context.Context, sub AnswerSubmission, score func(*pb.QuizQuestion) AnswerScore) (*pb.SubmitAnswerResponse, bool, error) {
+	if _, err := uuid.Parse(sub.QuestionID); err != nil {
+		return nil, false, ErrQuestionNotFound
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.answer_submissions (user_id, idempotency_key, question_id)
+		 VALUES ($1, $2, $3) ON CONFLICT (user_id, idempotency_key) DO NOTHING`,
+		sub.UserID, sub.IdempotencyKey, sub.QuestionID)
+	if err != nil {
+		return nil, false, err
+	}
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	if claimed == 0 {
+		// An earlier submit with this key has committed.
+		resp, err := r.storedSubmission(ctx, sub)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, true, nil
+	}
+
+	question, err := scanQuestion(tx.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM quiz.questions WHERE id = $1`, sub.QuestionID))
+	if err == sql.ErrNoRows {
+		return nil, false, ErrQuestionNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := useAttempt(ctx, tx, sub.AttemptID, sub.UserID, question.Id); err != nil {
+		return nil, false, err
+	}
+
+	s := score(question)
+	stats, err := updateStats(ctx, tx, sub.UserID, s.Correct, sub.Lives)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := saveAnswer(ctx, tx, sub.UserID, question, sub.SelectedIndex, s.Correct, s.XP, s.Coins); err != nil {
+		return nil, false, err
+	}
+	if err := creditCoins(ctx, tx, sub.UserID, s.Coins); err != nil {
+		return nil, false, err
+	}
+	if _, err := applyAnswerRating(ctx, tx, sub.UserID, question.Id, s.Correct); err != nil {
+		return nil, false, err
+	}
+	if err := updateReviewSchedule(ctx, tx, sub.UserID, question.Id, s.Correct); err != nil {
+		return nil, false, err
+	}
+
+	resp := &pb.SubmitAnswerResponse{
+		Correct:      s.Correct,
+		XpEarned:     s.XP,
+		CoinsEarned:  s.Coins,
+		Explanation:  question.Explanation,
+		StreakCount:  stats.CurrentStreak,
+		CorrectIndex: question.CorrectIndex,
+	}
+	stored, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE quiz.answer_submissions SET response = $3 WHERE user_id = $1 AND idempotency_key = $2`,
+		sub.UserID, sub.IdempotencyKey, stored); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return resp, false, nil
+}
+
+// storedSubmission returns the response saved for the submission's key.
+func (r *QuizRepository) storedSubmission(ctx context.Context, sub AnswerSubmission) (*pb.SubmitAnswerResponse, error) {
+	var questionID string
+	var stored []byte
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT question_id, response FROM quiz.answer_submissions WHERE user_id = $1 AND idempotency_key = $2`,
+		sub.UserID, sub.IdempotencyKey,
+	).Scan(&questionID, &stored); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(questionID, sub.QuestionID) {
+		return nil, ErrIdempotencyKeyReused
+	}
+	var resp pb.SubmitAnswerResponse
+	if err := proto.Unmarshal(stored, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
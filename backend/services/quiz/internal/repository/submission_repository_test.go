@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// testRepository connects to QUIZ_TEST_DATABASE_URL, a scratch database
+// loaded with scripts/init-db.sql, and skips the test when it is not set.
+// Tests use fresh user and question ids so they can share the database.
+func testRepository(t *testing.T) (*QuizRepository, *sql.DB) {
+	t.Helper()
+	url := os.Getenv("QUIZ_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("QUIZ_TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	return NewQuizRepository(db), db
+}
+
+func createTestQuestion(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	var id string
+	err := db.QueryRow(
+		`INSERT INTO quiz.questions (external_key, video_url, thumbnail_emoji, options, correct_index, explanation, difficulty, status)
+		 VALUES ($1, 'https://example.com/v.mp4', '🎬', ARRAY['real', 'fake'], 1, 'test', 'easy', 'published')
+		 RETURNING id`, "test-"+uuid.NewString(),
+	).Scan(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestSubmitAnswerReplay(t *testing.T) {
+	ctx := context.Background()
+	repo, db := testRepository(t)
+	userID := uuid.NewString()
+	questionID := createTestQuestion(t, db)
+	attemptID, err := repo.CreateAttempt(ctx, userID, questionID, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scored := 0
+	score := func(q *pb.QuizQuestion) AnswerScore {
+		scored++
+		return AnswerScore{Correct: true, XP: 10, Coins: 5}
+	}
+	sub := AnswerSubmission{
+		UserID:         userID,
+		QuestionID:     questionID,
+		AttemptID:      attemptID,
+		IdempotencyKey: uuid.NewString(),
+		SelectedIndex:  1,
+		Lives:          lives.Free,
+	}
+
+	first, replayed, err := repo.SubmitAnswer(ctx, sub, score)
+	if err != nil {
+		t.Fatalf("SubmitAnswer: %v", err)
+	}
+	if replayed {
+		t.Fatal("first submit reported as replayed")
+	}
+	second, replayed, err := repo.SubmitAnswer(ctx, sub, score)
+	if err != nil {
+		t.Fatalf("replayed SubmitAnswer: %v", err)
+	}
+	if !replayed {
+		t.Fatal("second submit not reported as replayed")
+	}
+	if !proto.Equal(first, second) {
+		t.Fatalf("replay returned %v, want %v", second, first)
+	}
+	if scored != 1 {
+		t.Fatalf("answer scored %d times, want 1", scored)
+	}
+
+	var answered, coins int32
+	if err := db.QueryRow(`SELECT total_answered, coins FROM quiz.user_stats WHERE user_id = $1`, userID).Scan(&answered, &coins); err != nil {
+		t.Fatal(err)
+	}
+	if answered != 1 {
+		t.Errorf("total_answered = %d, want 1", answered)
+	}
+	if want := int32(startingCoins + 5); coins != want {
+		t.Errorf("coins = %d, want %d", coins, want)
+	}
+}
+
+func TestSubmitAnswerKeyReusedForAnotherQuestion(t *testing.T) {
+	ctx := context.Background()
+	repo, db := testRepository(t)
+	userID := uuid.NewString()
+	questionID := createTestQuestion(t, db)
+	attemptID, err := repo.CreateAttempt(ctx, userID, questionID, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	score := func(q *pb.QuizQuestion) AnswerScore { return AnswerScore{Correct: true} }
+	sub := AnswerSubmission{
+		UserID:         userID,
+		QuestionID:     questionID,
+		AttemptID:      attemptID,
+		IdempotencyKey: uuid.NewString(),
+		SelectedIndex:  1,
+		Lives:          lives.Free,
+	}
+	if _, _, err := repo.SubmitAnswer(ctx, sub, score); err != nil {
+		t.Fatalf("SubmitAnswer: %v", err)
+	}
+
+	sub.QuestionID = createTestQuestion(t, db)
+	if _, _, err := repo.SubmitAnswer(ctx, sub, score); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("got %v, want %v", err, ErrIdempotencyKeyReused)
+	}
+}
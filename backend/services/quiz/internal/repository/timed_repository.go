@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+var (
+	ErrTimedSessionNotFound = errors.New("timed session not found")
+	ErrTimedSessionOver     = errors.New("timed session is over")
+	ErrNotCurrentQuestion   = errors.New("not the session's current question")
+
+	ErrInvalidLeaderboardPeriod = errors.New("invalid leaderboard period")
+)
+
+// TimedSessionRecord is a timed challenge session and its running totals.
+type TimedSessionRecord struct {
+	ID                string     `json:"id"`
+	Status            string     `json:"status"`
+	Difficulty        string     `json:"difficulty"`
+	QuestionCount     int32      `json:"question_count"`
+	AnsweredCount     int32      `json:"answered_count"`
+	CorrectCount      int32      `json:"correct_count"`
+	Score             int32      `json:"score"`
+	CurrentStreak     int32      `json:"current_streak"`
+	BestStreak        int32      `json:"best_streak"`
+	CurrentQuestionID string     `json:"-"`
+	XPEarned          int32      `json:"xp_earned"`
+	CoinsEarned       int32      `json:"coins_earned"`
+	StartedAt         time.Time  `json:"started_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+}
+
+const timedSessionColumns = `id, status, difficulty, question_count, answered_count, correct_count, score,
+	current_streak, best_streak, COALESCE(current_question_id::text, ''), xp_earned, coins_earned,
+	started_at, finished_at`
+
+func scanTimedSession(row scanner) (*TimedSessionRecord, error) {
+	var s TimedSessionRecord
+	var finishedAt sql.NullTime
+	if err := row.Scan(&s.ID, &s.Status, &s.Difficulty, &s.QuestionCount, &s.AnsweredCount, &s.CorrectCount,
+		&s.Score, &s.CurrentStreak, &s.BestStreak, &s.CurrentQuestionID, &s.XPEarned, &s.CoinsEarned,
+		&s.StartedAt, &finishedAt); err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		s.FinishedAt = &finishedAt.Time
+	}
+	return &s, nil
+}
+
+// TimedScore is what a timed answer earns.
+type TimedScore struct {
+	Correct  bool
+	TimedOut bool
+	Points   int32
+}
+
+// TimedAnswer is an answer recorded in a timed session. Next is the
+// question served after it, nil when the session has no more.
+type TimedAnswer struct {
+	Question *pb.QuizQuestion
+	Elapsed  time.Duration
+	Next     *pb.QuizQuestion
+	TimedScore
+}
+
+// CreateTimedSession starts a session serving firstQuestionID, abandoning
+// the user's other active sessions. Starting costs a life under policy; at
+// zero lives it fails with a *NoLivesLeftError.
+func (r *QuizRepository) CreateTimedSession(ctx context.Context, userID, difficulty string, questionCount int32, firstQuestionID string, policy lives.Policy) (*TimedSessionRecord, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := spendLife(ctx, tx, userID, policy); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE quiz.timed_sessions SET status = 'abandoned', current_question_id = NULL, finished_at = NOW()
+		 WHERE user_id = $1 AND status = 'active'`, userID); err != nil {
+		return nil, err
+	}
+	session, err := scanTimedSession(tx.QueryRowContext(ctx,
+		`INSERT INTO quiz.timed_sessions (user_id, difficulty, question_count, current_question_id, question_served_at)
+		 VALUES ($1, $2, $3, $4, NOW())
+		 RETURNING `+timedSessionColumns, userID, difficulty, questionCount, firstQuestionID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// TimedSessionProgress returns a session and the questions served in it so
+// far, the current one included, for picking the question after the
+// current one before RecordTimedAnswer opens its transaction.
+func (r *QuizRepository) TimedSessionProgress(ctx context.Context, userID, sessionID string) (*TimedSessionRecord, []string, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, nil, ErrTimedSessionNotFound
+	}
+	session, err := scanTimedSession(r.db.QueryRowContext(ctx,
+		`SELECT `+timedSessionColumns+` FROM quiz.timed_sessions WHERE id = $1 AND user_id = $2`,
+		sessionID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrTimedSessionNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	served, err := r.timedSessionQuestionIDs(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.CurrentQuestionID != "" {
+		served = append(served, session.CurrentQuestionID)
+	}
+	return session, served, nil
+}
+
+// RecordTimedAnswer answers the session's current question, scoring it with
+// score from how long after being served it came, by the database clock,
+// and the streak before it. Unless that was the session's last question,
+// next, picked beforehand from TimedSessionProgress, is served in the same
+// transaction. The session must still be on questionID, so next was picked
+// against the questions it has served. When next is nil the session is
+// left without a current question, to be finished.
+func (r *QuizRepository) RecordTimedAnswer(ctx context.Context, userID, sessionID, questionID string, selectedIndex int32,
+	score func(q *pb.QuizQuestion, elapsed time.Duration, streak int32) TimedScore,
+	next *pb.QuizQuestion) (*TimedAnswer, *TimedSessionRecord, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, nil, ErrTimedSessionNotFound
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var status, currentID string
+	var streak, answered, count int32
+	var elapsedMs float64
+	err = tx.QueryRowContext(ctx,
+		`SELECT status, COALESCE(current_question_id::text, ''), current_streak, answered_count, question_count,
+		        COALESCE(EXTRACT(EPOCH FROM NOW() - question_served_at) * 1000, 0)
+		 FROM quiz.timed_sessions WHERE id = $1 AND user_id = $2 FOR UPDATE`,
+		sessionID, userID,
+	).Scan(&status, &currentID, &streak, &answered, &count, &elapsedMs)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrTimedSessionNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if status != "active" {
+		return nil, nil, ErrTimedSessionOver
+	}
+	if currentID == "" || !strings.EqualFold(currentID, questionID) {
+		return nil, nil, ErrNotCurrentQuestion
+	}
+
+	question, err := scanQuestion(tx.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM quiz.questions WHERE id = $1`, currentID))
+	if err != nil {
+		return nil, nil, err
+	}
+	answer := &TimedAnswer{Question: question, Elapsed: time.Duration(elapsedMs * float64(time.Millisecond))}
+	answer.TimedScore = score(question, answer.Elapsed, streak)
+	if answer.Correct {
+		streak++
+	} else {
+		streak = 0
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quiz.timed_session_answers
+		   (session_id, question_id, selected_index, is_correct, timed_out, elapsed_ms, points)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		sessionID, question.Id, selectedIndex, answer.Correct, answer.TimedOut, answer.Elapsed.Milliseconds(), answer.Points); err != nil {
+		return nil, nil, err
+	}
+
+	var nextID interface{}
+	if answered+1 < count && next != nil {
+		answer.Next, nextID = next, next.Id
+	}
+
+	correct := 0
+	if answer.Correct {
+		correct = 1
+	}
+	session, err := scanTimedSession(tx.QueryRowContext(ctx,
+		`UPDATE quiz.timed_sessions
+		 SET answered_count = answered_count + 1, correct_count = correct_count + $2, score = score + $3,
+		     current_streak = $4, best_streak = GREATEST(best_streak, $4),
+		     current_question_id = $5, question_served_at = CASE WHEN $5::uuid IS NULL THEN NULL ELSE NOW() END
+		 WHERE id = $1
+		 RETURNING `+timedSessionColumns, sessionID, correct, answer.Points, streak, nextID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return answer, session, nil
+}
+
+// timedSessionQuestionIDs returns the questions answered in a session.
+func (r *QuizRepository) timedSessionQuestionIDs(ctx context.Context, sessionID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT question_id FROM quiz.timed_session_answers WHERE session_id = $1`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// FinishTimedSession ends an active session, paying it the XP and coins
+// rewards gives for its score, and reports whether this call finished it.
+// A session already finished is returned as it is.
+func (r *QuizRepository) FinishTimedSession(ctx context.Context, userID, sessionID string, rewards func(score int32) (xp, coins int32)) (*TimedSessionRecord, bool, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, false, ErrTimedSessionNotFound
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	session, err := scanTimedSession(tx.QueryRowContext(ctx,
+		`SELECT `+timedSessionColumns+` FROM quiz.timed_sessions WHERE id = $1 AND user_id = $2 FOR UPDATE`,
+		sessionID, userID))
+	if err == sql.ErrNoRows {
+		return nil, false, ErrTimedSessionNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	switch session.Status {
+	case "finished":
+		return session, false, nil
+	case "abandoned":
+		return nil, false, ErrTimedSessionOver
+	}
+
+	xp, coins := rewards(session.Score)
+	session, err = scanTimedSession(tx.QueryRowContext(ctx,
+		`UPDATE quiz.timed_sessions
+		 SET status = 'finished', xp_earned = $2, coins_earned = $3, finished_at = NOW(),
+		     current_question_id = NULL, question_served_at = NULL
+		 WHERE id = $1
+		 RETURNING `+timedSessionColumns, sessionID, xp, coins))
+	if err != nil {
+		return nil, false, err
+	}
+	if err := creditCoins(ctx, tx, userID, coins); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return session, true, nil
+}
+
+// Leaderboard periods.
+const (
+	LeaderboardDay  = "day"
+	LeaderboardWeek = "week"
+	LeaderboardAll  = "all"
+)
+
+// leaderboardSince is where each period starts, by the database clock.
+var leaderboardSince = map[string]string{
+	LeaderboardDay:  `date_trunc('day', NOW())`,
+	LeaderboardWeek: `NOW() - INTERVAL '7 days'`,
+	LeaderboardAll:  `'-infinity'::timestamp`,
+}
+
+// LeaderboardEntry is a player's best finished session in a period.
+type LeaderboardEntry struct {
+	Rank          int32
+	UserID        string
+	Score         int32
+	CorrectCount  int32
+	QuestionCount int32
+	FinishedAt    time.Time
+}
+
+// TimedLeaderboard returns the top limit players by their best session
+// finished in period, and userID's own entry, nil when they have none.
+func (r *QuizRepository) TimedLeaderboard(ctx context.Context, userID, period string, limit int) ([]LeaderboardEntry, *LeaderboardEntry, error) {
+	since, ok := leaderboardSince[period]
+	if !ok {
+		return nil, nil, ErrInvalidLeaderboardPeriod
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`WITH best AS (
+		     SELECT DISTINCT ON (user_id) user_id, score, correct_count, question_count, finished_at
+		     FROM quiz.timed_sessions
+		     WHERE status = 'finished' AND finished_at >= `+since+`
+		     ORDER BY user_id, score DESC, finished_at
+		 ), ranked AS (
+		     SELECT ROW_NUMBER() OVER (ORDER BY score DESC, finished_at) AS rank, * FROM best
+		 )
+		 SELECT rank, user_id, score, correct_count, question_count, finished_at
+		 FROM ranked WHERE rank <= $1 OR user_id = $2 ORDER BY rank`, limit, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	entries := []LeaderboardEntry{}
+	var me *LeaderboardEntry
+	for rows.Next() {
+		var e LeaderboardEntry
+		if err := rows.Scan(&e.Rank, &e.UserID, &e.Score, &e.CorrectCount, &e.QuestionCount, &e.FinishedAt); err != nil {
+			return nil, nil, err
+		}
+		if strings.EqualFold(e.UserID, userID) {
+			mine := e
+			me = &mine
+		}
+		if e.Rank <= int32(limit) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, me, rows.Err()
+}
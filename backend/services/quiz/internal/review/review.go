@@ -0,0 +1,31 @@
+// Package review schedules missed questions for spaced repetition with
+// Leitner boxes. A miss puts the question in the first box; each correct
+// review moves it up a box, where it waits longer before coming back, and
+// a correct review from the last box retires it.
+package review
+
+import "time"
+
+// Intervals is how long a question waits in each box, first box first,
+// before its review is due.
+var Intervals = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// Next returns the box a question in box moves to after an answer and how
+// long until it is due again. box is 0 for a question that is not
+// scheduled. done reports that the question has been learned and leaves the
+// schedule.
+func Next(box int, correct bool) (next int, wait time.Duration, done bool) {
+	if !correct {
+		return 1, Intervals[0], false
+	}
+	if box >= len(Intervals) {
+		return 0, 0, true
+	}
+	return box + 1, Intervals[box], false
+}
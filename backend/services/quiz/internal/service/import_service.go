@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pawfiler/backend/services/quiz/internal/questionbank"
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+var (
+	ErrUnknownBankFormat = errors.New("format must be jsonl or csv")
+	ErrImportTooLarge    = errors.New("import file too large")
+	ErrInvalidImportFile = errors.New("invalid import file")
+)
+
+const (
+	maxImportBytes = 10 << 20
+	maxImportRows  = 5000
+)
+
+// ImportQuestions validates every row of a question bank file and, unless
+// dryRun is set or a row is invalid, upserts all of them by external key in
+// one transaction. Rows default to draft status.
+func (s *QuizService) ImportQuestions(ctx context.Context, authorID, format string, data []byte, dryRun bool) (*pb.ImportQuestionsResponse, error) {
+	if format != questionbank.FormatJSONL && format != questionbank.FormatCSV {
+		return nil, ErrUnknownBankFormat
+	}
+	if len(data) > maxImportBytes {
+		return nil, ErrImportTooLarge
+	}
+	rows, err := questionbank.Decode(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	if len(rows) > maxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	resp := &pb.ImportQuestionsResponse{}
+	rowError := func(row questionbank.Row, err error) {
+		key := ""
+		if row.Question != nil {
+			key = row.Question.ExternalKey
+		}
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{Row: int32(row.Line), ExternalKey: key, Message: err.Error()})
+	}
+
+	valid := make([]questionbank.Row, 0, len(rows))
+	seen := make(map[string]int)
+	for _, row := range rows {
+		if row.Err != nil {
+			rowError(row, row.Err)
+			continue
+		}
+		if err := normalizeImportedQuestion(row.Question); err != nil {
+			rowError(row, err)
+			continue
+		}
+		if line, dup := seen[row.Question.ExternalKey]; dup {
+			rowError(row, fmt.Errorf("external_key also used on row %d", line))
+			continue
+		}
+		seen[row.Question.ExternalKey] = row.Line
+		valid = append(valid, row)
+	}
+
+	keys := make([]string, 0, len(valid))
+	for _, row := range valid {
+		keys = append(keys, row.Question.ExternalKey)
+	}
+	statuses, err := s.repo.StatusesByExternalKey(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*pb.QuizQuestion
+	for _, row := range valid {
+		q := row.Question
+		current, exists := statuses[q.ExternalKey]
+		switch {
+		case current == repository.QuestionStatusArchived && q.Status == repository.QuestionStatusArchived:
+			resp.Unchanged++
+		case current == repository.QuestionStatusArchived:
+			rowError(row, repository.ErrQuestionArchived)
+		case exists:
+			resp.Updated++
+			changes = append(changes, q)
+		default:
+			resp.Created++
+			changes = append(changes, q)
+		}
+	}
+
+	if dryRun || len(resp.Errors) > 0 || len(changes) == 0 {
+		return resp, nil
+	}
+	created, updated, err := s.repo.UpsertQuestions(ctx, changes, authorID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Created, resp.Updated, resp.Applied = int32(created), int32(updated), true
+	log.Printf("Imported questions: %d created, %d updated by %s", created, updated, authorID)
+	return resp, nil
+}
+
+// normalizeImportedQuestion applies the same checks as CreateQuestion plus
+// the ones only imports need: a key to match on and an explicit status.
+func normalizeImportedQuestion(q *pb.QuizQuestion) error {
+	if strings.TrimSpace(q.ExternalKey) == "" {
+		return errors.New("external_key is required")
+	}
+	q.Status = strings.ToLower(strings.TrimSpace(q.Status))
+	if q.Status == "" {
+		q.Status = repository.QuestionStatusDraft
+	}
+	if !isQuestionStatus(q.Status) {
+		return ErrInvalidStatus
+	}
+	return normalizeQuestion(q)
+}
+
+// ExportQuestions writes the questions matching the filter as a question
+// bank file that ImportQuestions accepts.
+func (s *QuizService) ExportQuestions(ctx context.Context, req *pb.ExportQuestionsRequest) (*pb.ExportQuestionsResponse, error) {
+	format := req.Format
+	if format == "" {
+		format = questionbank.FormatJSONL
+	}
+	if format != questionbank.FormatJSONL && format != questionbank.FormatCSV {
+		return nil, ErrUnknownBankFormat
+	}
+	if req.Status != nil && !isQuestionStatus(*req.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	filter := repository.QuestionFilter{Difficulty: req.Difficulty, Status: req.Status}
+	if req.Tag != nil {
+		tag := normalizeTag(*req.Tag)
+		filter.Tag = &tag
+	}
+	questions, _, err := s.repo.ListQuestions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := questionbank.Encode(format, &buf, questions); err != nil {
+		return nil, err
+	}
+	return &pb.ExportQuestionsResponse{
+		Filename:    "questions-" + time.Now().UTC().Format("20060102") + "." + format,
+		ContentType: questionbank.ContentType(format),
+		Data:        buf.Bytes(),
+		Count:       int32(len(questions)),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
+	"github.com/pawfiler/backend/services/quiz/pkg/kafka"
+)
+
+const outboxBatchSize = 100
+
+// OutboxRelay publishes events queued in the outbox, such as
+// quiz.coin_balance_changed, oldest first. An event that fails to publish is
+// tried again on the next pass, and one published just before a crash may
+// go out twice, so consumers must tolerate duplicates.
+type OutboxRelay struct {
+	repo     *repository.QuizRepository
+	producer *kafka.Producer
+	interval time.Duration
+}
+
+func NewOutboxRelay(repo *repository.QuizRepository, producer *kafka.Producer) *OutboxRelay {
+	return &OutboxRelay{
+		repo:     repo,
+		producer: producer,
+		interval: time.Second,
+	}
+}
+
+// Run publishes pending events every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.publishPending(ctx); err != nil {
+			log.Printf("failed to publish outbox events: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishPending stops at the first failure so events keep their order.
+func (r *OutboxRelay) publishPending(ctx context.Context) error {
+	for {
+		events, err := r.repo.PendingEvents(ctx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := r.producer.Emit(e.Type, e.Payload); err != nil {
+				return err
+			}
+			if err := r.repo.MarkEventPublished(ctx, e.ID); err != nil {
+				return err
+			}
+		}
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
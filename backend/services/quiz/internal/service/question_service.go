@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pawfiler/backend/services/quiz/internal/questionbank"
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+var (
+	ErrMissingQuestionFields  = errors.New("video url, thumbnail and explanation are required")
+	ErrInvalidVideoURL        = errors.New("video url must be an http or https URL")
+	ErrInvalidOptions         = errors.New("a question needs 2 to 6 distinct, non-empty options")
+	ErrCorrectIndexOutOfRange = errors.New("correct index out of range")
+	ErrInvalidDifficulty      = errors.New("unknown difficulty")
+	ErrInvalidTags            = errors.New("too many tags or tag too long")
+	ErrTagHasSeparator        = errors.New(`tags cannot contain ";"`)
+	ErrInvalidStatus          = errors.New("invalid question status")
+	ErrInvalidExternalKey     = errors.New("invalid external key")
+	ErrInvalidCategory        = errors.New("unknown category")
+)
+
+const (
+	minOptions      = 2
+	maxOptions      = 6
+	maxTags         = 10
+	maxTagLength    = 30
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var difficulties = []string{"easy", "medium", "hard"}
+
+// categories are the kinds of manipulation a question's video can show.
+var categories = []string{"authentic", "face_swap", "lip_sync", "face_reenactment", "synthetic", "edited"}
+
+var externalKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]{0,99}$`)
+
+// CreateQuestion saves a new draft question by authorID.
+func (s *QuizService) CreateQuestion(ctx context.Context, authorID string, req *pb.CreateQuestionRequest) (*pb.QuizQuestion, error) {
+	q := &pb.QuizQuestion{
+		VideoUrl:       req.VideoUrl,
+		ThumbnailEmoji: req.ThumbnailEmoji,
+		Options:        req.Options,
+		CorrectIndex:   req.CorrectIndex,
+		Explanation:    req.Explanation,
+		Difficulty:     req.Difficulty,
+		Tags:           req.Tags,
+		ExternalKey:    req.ExternalKey,
+		Category:       req.Category,
+	}
+	if err := normalizeQuestion(q); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateQuestion(ctx, q, authorID)
+}
+
+// UpdateQuestion replaces a question's content and can publish it or move it
+// back to draft. Archiving goes through ArchiveQuestion.
+func (s *QuizService) UpdateQuestion(ctx context.Context, req *pb.UpdateQuestionRequest) (*pb.QuizQuestion, error) {
+	current, err := s.repo.GetQuestionById(ctx, req.QuestionId)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == repository.QuestionStatusArchived {
+		return nil, repository.ErrQuestionArchived
+	}
+
+	status := req.Status
+	if status == "" {
+		status = current.Status
+	}
+	if status != repository.QuestionStatusDraft && status != repository.QuestionStatusPublished {
+		return nil, ErrInvalidStatus
+	}
+
+	q := &pb.QuizQuestion{
+		Id:             current.Id,
+		VideoUrl:       req.VideoUrl,
+		ThumbnailEmoji: req.ThumbnailEmoji,
+		Options:        req.Options,
+		CorrectIndex:   req.CorrectIndex,
+		Explanation:    req.Explanation,
+		Difficulty:     req.Difficulty,
+		Tags:           req.Tags,
+		Status:         status,
+		ExternalKey:    req.ExternalKey,
+		Category:       req.Category,
+	}
+	if err := normalizeQuestion(q); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateQuestion(ctx, q)
+}
+
+// ArchiveQuestion stops a question being served. Past answers to it are
+// kept.
+func (s *QuizService) ArchiveQuestion(ctx context.Context, questionID string) (*pb.QuizQuestion, error) {
+	return s.repo.ArchiveQuestion(ctx, questionID)
+}
+
+func (s *QuizService) ListQuestions(ctx context.Context, req *pb.ListQuestionsRequest) (*pb.ListQuestionsResponse, error) {
+	if req.Status != nil && !isQuestionStatus(*req.Status) {
+		return nil, ErrInvalidStatus
+	}
+	page, pageSize := pageBounds(req.Page, req.PageSize)
+
+	filter := repository.QuestionFilter{
+		Difficulty: req.Difficulty,
+		Status:     req.Status,
+		Limit:      int(pageSize),
+		Offset:     int((page - 1) * pageSize),
+	}
+	if req.Tag != nil {
+		tag := normalizeTag(*req.Tag)
+		filter.Tag = &tag
+	}
+	questions, total, err := s.repo.ListQuestions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListQuestionsResponse{Questions: questions, TotalCount: total, Page: page}, nil
+}
+
+// normalizeQuestion trims q's fields and checks that it can be played:
+// enough distinct options, a correct index among them and a known
+// difficulty. The external key and category are optional.
+func normalizeQuestion(q *pb.QuizQuestion) error {
+	q.ExternalKey = strings.TrimSpace(q.ExternalKey)
+	if q.ExternalKey != "" && !externalKeyPattern.MatchString(q.ExternalKey) {
+		return ErrInvalidExternalKey
+	}
+	q.VideoUrl = strings.TrimSpace(q.VideoUrl)
+	q.ThumbnailEmoji = strings.TrimSpace(q.ThumbnailEmoji)
+	q.Explanation = strings.TrimSpace(q.Explanation)
+	q.Difficulty = strings.ToLower(strings.TrimSpace(q.Difficulty))
+	if q.VideoUrl == "" || q.ThumbnailEmoji == "" || q.Explanation == "" {
+		return ErrMissingQuestionFields
+	}
+	if u, err := url.Parse(q.VideoUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidVideoURL
+	}
+
+	if len(q.Options) < minOptions || len(q.Options) > maxOptions {
+		return ErrInvalidOptions
+	}
+	options := make([]string, len(q.Options))
+	for i, opt := range q.Options {
+		opt = strings.TrimSpace(opt)
+		if opt == "" || slices.Contains(options[:i], opt) {
+			return ErrInvalidOptions
+		}
+		options[i] = opt
+	}
+	q.Options = options
+	if q.CorrectIndex < 0 || int(q.CorrectIndex) >= len(q.Options) {
+		return ErrCorrectIndexOutOfRange
+	}
+
+	if !slices.Contains(difficulties, q.Difficulty) {
+		return ErrInvalidDifficulty
+	}
+	q.Category = strings.ToLower(strings.TrimSpace(q.Category))
+	if q.Category != "" && !slices.Contains(categories, q.Category) {
+		return ErrInvalidCategory
+	}
+
+	tags := []string{}
+	for _, tag := range q.Tags {
+		tag = normalizeTag(tag)
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return ErrInvalidTags
+		}
+		// The tag would come back as two from a CSV export.
+		if strings.Contains(tag, questionbank.TagSeparator) {
+			return ErrTagHasSeparator
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTags {
+		return ErrInvalidTags
+	}
+	q.Tags = tags
+	return nil
+}
+
+// pageBounds fills in the first page and the default page size, and caps
+// the page size.
+func pageBounds(page, pageSize int32) (int32, int32) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func isQuestionStatus(status string) bool {
+	return status == repository.QuestionStatusDraft ||
+		status == repository.QuestionStatusPublished ||
+		status == repository.QuestionStatusArchived
+}
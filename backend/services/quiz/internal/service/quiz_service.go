@@ -2,76 +2,275 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	"github.com/pawfiler/backend/services/quiz/internal/rating"
 	"github.com/pawfiler/backend/services/quiz/internal/repository"
 	"github.com/pawfiler/backend/services/quiz/pkg/kafka"
 )
 
+// attemptTTL is how long a served question can be answered for.
+const attemptTTL = 30 * time.Minute
+
+// refillCostPerLife is what RefillLives charges for each missing life.
+const refillCostPerLife = 10
+
+const (
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+)
+
+var ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 100 characters")
+
+// maxIdempotencyKeyLength matches quiz.answer_submissions.idempotency_key.
+const maxIdempotencyKeyLength = 100
+
 type QuizService struct {
 	repo     *repository.QuizRepository
 	producer *kafka.Producer
+	// recentWindow is how many of a player's latest answers GetRandomQuestion
+	// avoids repeating; 0 turns it off.
+	recentWindow int
 }
 
-func NewQuizService(repo *repository.QuizRepository, producer *kafka.Producer) *QuizService {
+func NewQuizService(repo *repository.QuizRepository, producer *kafka.Producer, recentWindow int) *QuizService {
 	return &QuizService{
-		repo:     repo,
-		producer: producer,
+		repo:         repo,
+		producer:     producer,
+		recentWindow: recentWindow,
 	}
 }
 
-func (s *QuizService) GetRandomQuestion(ctx context.Context, userID string, difficulty *string) (*pb.QuizQuestion, error) {
-	return s.repo.GetRandomQuestion(ctx, difficulty)
+// GetRandomQuestion serves a question of the requested difficulty, or when
+// none is requested one matched to the player's rating. Questions from the
+// player's recent answers are skipped while others are left.
+func (s *QuizService) GetRandomQuestion(ctx context.Context, userID string, difficulty *string) (*pb.Question, error) {
+	question, err := s.pickQuestion(ctx, userID, difficulty, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.serve(ctx, userID, question)
 }
 
-func (s *QuizService) SubmitAnswer(ctx context.Context, userID, questionID string, selectedIndex int32) (*pb.SubmitAnswerResponse, error) {
-	question, err := s.repo.GetQuestionById(ctx, questionID)
+// pickQuestion picks a question for GetRandomQuestion, never one of
+// exclude.
+func (s *QuizService) pickQuestion(ctx context.Context, userID string, difficulty *string, exclude []string) (*pb.QuizQuestion, error) {
+	sel := repository.QuestionSelection{Difficulty: difficulty}
+	if difficulty == nil || *difficulty == "" {
+		playerRating, err := s.repo.GetUserRating(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		target := rating.Target(playerRating)
+		sel.TargetRating = &target
+	}
+
+	var recent []string
+	if s.recentWindow > 0 {
+		var err error
+		if recent, err = s.repo.RecentQuestionIDs(ctx, userID, s.recentWindow); err != nil {
+			return nil, err
+		}
+	}
+
+	// Once the player has seen everything that matches, settle for not
+	// repeating the last question, then for any question.
+	skips := [][]string{recent}
+	if len(recent) > 1 {
+		skips = append(skips, recent[:1])
+	}
+	if len(recent) > 0 {
+		skips = append(skips, nil)
+	}
+	var question *pb.QuizQuestion
+	var err error
+	for _, skip := range skips {
+		sel.Exclude = append(skip[:len(skip):len(skip)], exclude...)
+		question, err = s.repo.GetRandomQuestion(ctx, sel)
+		if !errors.Is(err, repository.ErrQuestionNotFound) {
+			break
+		}
+	}
+	return question, err
+}
+
+// SubmitAnswer checks an answer to a question served with attemptID. Each
+// attempt can be answered once, and only while the player has lives left
+// under their subscription's policy. Submitting an idempotency key again
+// returns the first response without counting the answer twice.
+func (s *QuizService) SubmitAnswer(ctx context.Context, userID, subscription string, req *pb.SubmitAnswerRequest) (*pb.SubmitAnswerResponse, error) {
+	if req.AttemptId == "" {
+		return nil, repository.ErrAttemptInvalid
+	}
+	key := req.IdempotencyKey
+	if key == "" {
+		key = req.AttemptId
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	resp, replayed, err := s.repo.SubmitAnswer(ctx, repository.AnswerSubmission{
+		UserID:         userID,
+		QuestionID:     req.QuestionId,
+		AttemptID:      req.AttemptId,
+		IdempotencyKey: key,
+		SelectedIndex:  req.SelectedIndex,
+		Lives:          lives.For(subscription),
+	}, func(question *pb.QuizQuestion) repository.AnswerScore {
+		if question.CorrectIndex != req.SelectedIndex {
+			return repository.AnswerScore{}
+		}
+		return repository.AnswerScore{Correct: true, XP: 10, Coins: 5}
+	})
 	if err != nil {
 		return nil, err
 	}
+	if replayed {
+		return resp, nil
+	}
+
+	// Emit event
+	s.producer.Emit("quiz.answered", map[string]interface{}{
+		"user_id":      userID,
+		"question_id":  req.QuestionId,
+		"correct":      resp.Correct,
+		"xp_earned":    resp.XpEarned,
+		"coins_earned": resp.CoinsEarned,
+	})
 
-	correct := question.CorrectIndex == selectedIndex
-	xpEarned := int32(0)
-	coinsEarned := int32(0)
+	return resp, nil
+}
 
-	if correct {
-		xpEarned = 10
-		coinsEarned = 5
+// GetUserStats returns the player's totals, breakdowns and a daily series
+// over the last days days.
+func (s *QuizService) GetUserStats(ctx context.Context, userID, subscription string, days int32) (*pb.QuizStats, error) {
+	if days < 1 {
+		days = defaultStatsDays
+	}
+	if days > maxStatsDays {
+		days = maxStatsDays
+	}
+
+	stats, err := s.repo.GetUserStats(ctx, userID, lives.For(subscription))
+	if err != nil {
+		return nil, err
 	}
+	if stats.ReviewsDue, err = s.repo.CountDueReviews(ctx, userID); err != nil {
+		return nil, err
+	}
+	if stats.ByDifficulty, err = s.repo.BreakdownByDifficulty(ctx, userID); err != nil {
+		return nil, err
+	}
+	if stats.ByCategory, err = s.repo.BreakdownByCategory(ctx, userID); err != nil {
+		return nil, err
+	}
+	if stats.Daily, err = s.repo.DailyAnswers(ctx, userID, int(days)); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
 
-	err = s.repo.SaveAnswer(ctx, userID, questionID, selectedIndex, correct, xpEarned, coinsEarned)
+// GetAnswerHistory pages through the player's answers, newest first.
+func (s *QuizService) GetAnswerHistory(ctx context.Context, userID string, req *pb.GetAnswerHistoryRequest) (*pb.GetAnswerHistoryResponse, error) {
+	page, pageSize := pageBounds(req.Page, req.PageSize)
+	answers, total, err := s.repo.AnswerHistory(ctx, userID, int(pageSize), int((page-1)*pageSize))
 	if err != nil {
 		return nil, err
 	}
+	return &pb.GetAnswerHistoryResponse{Answers: answers, TotalCount: total, Page: page}, nil
+}
 
-	stats, err := s.repo.UpdateStats(ctx, userID, correct)
+// RefillLives buys back the player's missing lives from their coin balance.
+func (s *QuizService) RefillLives(ctx context.Context, userID, subscription string) (*pb.RefillLivesResponse, error) {
+	policy := lives.For(subscription)
+	spent, left, err := s.repo.RefillLives(ctx, userID, policy, refillCostPerLife)
 	if err != nil {
 		return nil, err
 	}
 
-	// Emit event
-	s.producer.Emit("quiz.answered", map[string]interface{}{
-		"user_id":      userID,
-		"question_id":  questionID,
-		"correct":      correct,
-		"xp_earned":    xpEarned,
-		"coins_earned": coinsEarned,
+	s.producer.Emit("quiz.coins_spent", map[string]interface{}{
+		"user_id": userID,
+		"coins":   spent,
+		"reason":  "lives_refill",
 	})
 
-	return &pb.SubmitAnswerResponse{
-		Correct:      correct,
-		XpEarned:     xpEarned,
-		CoinsEarned:  coinsEarned,
-		Explanation:  question.Explanation,
-		StreakCount:  stats.CurrentStreak,
+	return &pb.RefillLivesResponse{
+		Lives:      policy.Max,
+		MaxLives:   policy.Max,
+		CoinsSpent: spent,
+		CoinsLeft:  left,
 	}, nil
 }
 
-func (s *QuizService) GetUserStats(ctx context.Context, userID string) (*pb.QuizStats, error) {
-	return s.repo.GetUserStats(ctx, userID)
+// GetReviewQuestion serves the player's most overdue missed question.
+func (s *QuizService) GetReviewQuestion(ctx context.Context, userID string) (*pb.Question, error) {
+	question, err := s.repo.GetDueReviewQuestion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.serve(ctx, userID, question)
+}
+
+func (s *QuizService) GetQuestionById(ctx context.Context, userID, questionID string) (*pb.Question, error) {
+	question, err := s.repo.GetQuestionById(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status != repository.QuestionStatusPublished {
+		return nil, repository.ErrQuestionNotFound
+	}
+	return s.serve(ctx, userID, question)
+}
+
+// serve records an attempt for userID and strips the answer from question.
+func (s *QuizService) serve(ctx context.Context, userID string, question *pb.QuizQuestion) (*pb.Question, error) {
+	attemptID, err := s.repo.CreateAttempt(ctx, userID, question.Id, attemptTTL)
+	if err != nil {
+		return nil, err
+	}
+	served := publicQuestion(question)
+	served.AttemptId = attemptID
+	served.AttemptExpiresIn = int64(attemptTTL.Seconds())
+	return served, nil
 }
 
-func (s *QuizService) GetQuestionById(ctx context.Context, questionID string) (*pb.QuizQuestion, error) {
-	return s.repo.GetQuestionById(ctx, questionID)
+// publicQuestion is question without its answer.
+func publicQuestion(question *pb.QuizQuestion) *pb.Question {
+	return &pb.Question{
+		Id:             question.Id,
+		VideoUrl:       question.VideoUrl,
+		ThumbnailEmoji: question.ThumbnailEmoji,
+		Options:        question.Options,
+		Difficulty:     question.Difficulty,
+	}
+}
+
+// ExportUserData returns what the quiz service stores about the user for
+// their personal data export.
+func (s *QuizService) ExportUserData(ctx context.Context, userID string) (*repository.UserData, error) {
+	return s.repo.ExportUserData(ctx, userID)
+}
+
+// HandleUserDeleted erases a user's answers and stats once auth publishes
+// user.deleted for them.
+func (s *QuizService) HandleUserDeleted(ctx context.Context, payload json.RawMessage) error {
+	var event struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.UserID == "" {
+		log.Printf("Ignoring malformed user.deleted event: %s", payload)
+		return nil
+	}
+	if err := s.repo.DeleteUserData(ctx, event.UserID); err != nil {
+		return fmt.Errorf("delete quiz data for user %s: %w", event.UserID, err)
+	}
+	log.Printf("Deleted quiz data for user %s", event.UserID)
+	return nil
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pawfiler/backend/services/quiz/internal/lives"
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
+	"github.com/pawfiler/backend/services/quiz/internal/timed"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+)
+
+const (
+	defaultTimedQuestions = 10
+	maxTimedQuestions     = 20
+
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 100
+)
+
+// StartTimedSession starts a timed challenge and serves its first question.
+// Starting costs a life under the player's subscription's policy.
+func (s *QuizService) StartTimedSession(ctx context.Context, userID, subscription string, req *pb.StartTimedSessionRequest) (*pb.TimedSession, error) {
+	count := req.QuestionCount
+	if count < 1 {
+		count = defaultTimedQuestions
+	}
+	if count > maxTimedQuestions {
+		count = maxTimedQuestions
+	}
+
+	question, err := s.pickQuestion(ctx, userID, req.Difficulty, nil)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.repo.CreateTimedSession(ctx, userID, req.GetDifficulty(), count, question.Id, lives.For(subscription))
+	if err != nil {
+		return nil, err
+	}
+	return timedSession(session, question), nil
+}
+
+// SubmitTimedAnswer scores the answer to a session's current question and
+// serves the next one, or finishes the session after its last question or
+// once no unseen question is left.
+func (s *QuizService) SubmitTimedAnswer(ctx context.Context, userID string, req *pb.SubmitTimedAnswerRequest) (*pb.TimedAnswerResult, error) {
+	// The next question is picked before the answer's transaction opens, so
+	// picking never holds the session's lock or a second connection.
+	next, err := s.pickNextTimedQuestion(ctx, userID, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, session, err := s.repo.RecordTimedAnswer(ctx, userID, req.SessionId, req.QuestionId, req.SelectedIndex,
+		func(question *pb.QuizQuestion, elapsed time.Duration, streak int32) repository.TimedScore {
+			if timed.TimedOut(elapsed) {
+				return repository.TimedScore{TimedOut: true}
+			}
+			if question.CorrectIndex != req.SelectedIndex {
+				return repository.TimedScore{}
+			}
+			return repository.TimedScore{Correct: true, Points: timed.Points(elapsed, streak+1)}
+		},
+		next)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.TimedAnswerResult{
+		Correct:      answer.Correct,
+		TimedOut:     answer.TimedOut,
+		CorrectIndex: answer.Question.CorrectIndex,
+		Explanation:  answer.Question.Explanation,
+		ElapsedMs:    answer.Elapsed.Milliseconds(),
+		Points:       answer.Points,
+		Streak:       session.CurrentStreak,
+		Score:        session.Score,
+	}
+	if answer.Next != nil {
+		result.Next = timedSession(session, answer.Next)
+		return result, nil
+	}
+
+	if result.Result, err = s.FinishTimedSession(ctx, userID, session.ID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pickNextTimedQuestion picks the question to serve after the session's
+// current one, nil when that is its last or no unseen question is left.
+func (s *QuizService) pickNextTimedQuestion(ctx context.Context, userID, sessionID string) (*pb.QuizQuestion, error) {
+	session, served, err := s.repo.TimedSessionProgress(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != "active" || session.AnsweredCount+1 >= session.QuestionCount {
+		return nil, nil
+	}
+	var difficulty *string
+	if session.Difficulty != "" {
+		difficulty = &session.Difficulty
+	}
+	question, err := s.pickQuestion(ctx, userID, difficulty, served)
+	if errors.Is(err, repository.ErrQuestionNotFound) {
+		return nil, nil
+	}
+	return question, err
+}
+
+// FinishTimedSession ends a session and pays out its XP and coins. Finishing
+// it again returns the same result.
+func (s *QuizService) FinishTimedSession(ctx context.Context, userID, sessionID string) (*pb.TimedSessionResult, error) {
+	session, finished, err := s.repo.FinishTimedSession(ctx, userID, sessionID, timed.Rewards)
+	if err != nil {
+		return nil, err
+	}
+
+	if finished {
+		s.producer.Emit("quiz.timed_session_finished", map[string]interface{}{
+			"user_id":        userID,
+			"session_id":     session.ID,
+			"score":          session.Score,
+			"correct_count":  session.CorrectCount,
+			"question_count": session.QuestionCount,
+			"xp_earned":      session.XPEarned,
+			"coins_earned":   session.CoinsEarned,
+		})
+	}
+
+	result := &pb.TimedSessionResult{
+		SessionId:     session.ID,
+		Score:         session.Score,
+		QuestionCount: session.QuestionCount,
+		AnsweredCount: session.AnsweredCount,
+		CorrectCount:  session.CorrectCount,
+		BestStreak:    session.BestStreak,
+		XpEarned:      session.XPEarned,
+		CoinsEarned:   session.CoinsEarned,
+		StartedAt:     session.StartedAt.Format(time.RFC3339),
+	}
+	if session.FinishedAt != nil {
+		result.FinishedAt = session.FinishedAt.Format(time.RFC3339)
+	}
+	return result, nil
+}
+
+// timedSession describes question as the session's current question.
+func timedSession(session *repository.TimedSessionRecord, question *pb.QuizQuestion) *pb.TimedSession {
+	return &pb.TimedSession{
+		SessionId:      session.ID,
+		Question:       publicQuestion(question),
+		QuestionNumber: session.AnsweredCount + 1,
+		QuestionCount:  session.QuestionCount,
+		TimeLimitMs:    timed.QuestionTimeLimit.Milliseconds(),
+		Score:          session.Score,
+	}
+}
+
+// GetTimedLeaderboard ranks players by their best timed session finished in
+// the requested period, a week by default.
+func (s *QuizService) GetTimedLeaderboard(ctx context.Context, userID string, req *pb.GetTimedLeaderboardRequest) (*pb.TimedLeaderboard, error) {
+	period := req.Period
+	if period == "" {
+		period = repository.LeaderboardWeek
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = defaultLeaderboardSize
+	}
+	if limit > maxLeaderboardSize {
+		limit = maxLeaderboardSize
+	}
+
+	entries, me, err := s.repo.TimedLeaderboard(ctx, userID, period, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	board := &pb.TimedLeaderboard{Period: period, Entries: make([]*pb.TimedLeaderboardEntry, 0, len(entries))}
+	for _, e := range entries {
+		board.Entries = append(board.Entries, leaderboardEntry(e))
+	}
+	if me != nil {
+		board.Me = leaderboardEntry(*me)
+	}
+	return board, nil
+}
+
+func leaderboardEntry(e repository.LeaderboardEntry) *pb.TimedLeaderboardEntry {
+	return &pb.TimedLeaderboardEntry{
+		Rank:          e.Rank,
+		UserId:        e.UserID,
+		Score:         e.Score,
+		CorrectCount:  e.CorrectCount,
+		QuestionCount: e.QuestionCount,
+		FinishedAt:    e.FinishedAt.Format(time.RFC3339),
+	}
+}
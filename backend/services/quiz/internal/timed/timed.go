@@ -0,0 +1,43 @@
+// Package timed scores the timed challenge mode. Every question has to be
+// answered within QuestionTimeLimit of being served; correct answers earn
+// base points, a bonus for answering fast and a multiplier for keeping a
+// streak going.
+package timed
+
+import "time"
+
+const (
+	QuestionTimeLimit = 20 * time.Second
+	// Grace is added to the limit so network latency does not turn an
+	// answer given in time into a timeout. Answers in it earn no speed
+	// bonus.
+	Grace = 2 * time.Second
+
+	basePoints    = 100
+	maxSpeedBonus = 100
+	// A streak adds 10% per correct answer in a row before this one, up to
+	// maxStreakBonus steps.
+	maxStreakBonus = 5
+)
+
+// TimedOut reports whether an answer elapsed after being served is too late
+// to count.
+func TimedOut(elapsed time.Duration) bool {
+	return elapsed > QuestionTimeLimit+Grace
+}
+
+// Points is what a correct answer given elapsed after being served earns
+// as the streak-th correct answer in a row.
+func Points(elapsed time.Duration, streak int32) int32 {
+	bonus := 0.0
+	if elapsed < QuestionTimeLimit {
+		bonus = maxSpeedBonus * (1 - float64(max(elapsed, 0))/float64(QuestionTimeLimit))
+	}
+	multiplier := 1 + 0.1*float64(min(max(streak-1, 0), maxStreakBonus))
+	return int32((basePoints + bonus) * multiplier)
+}
+
+// Rewards converts a finished session's score to XP and coins.
+func Rewards(score int32) (xp, coins int32) {
+	return score / 20, score / 40
+}
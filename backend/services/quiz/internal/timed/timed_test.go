@@ -0,0 +1,71 @@
+package timed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimedOut(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		want    bool
+	}{
+		{0, false},
+		{QuestionTimeLimit, false},
+		{QuestionTimeLimit + Grace, false},
+		{QuestionTimeLimit + Grace + time.Millisecond, true},
+		{time.Hour, true},
+	}
+	for _, tt := range tests {
+		if got := TimedOut(tt.elapsed); got != tt.want {
+			t.Errorf("TimedOut(%s) = %v, want %v", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		streak  int32
+		want    int32
+	}{
+		{"instant", 0, 1, 200},
+		{"half the limit", QuestionTimeLimit / 2, 1, 150},
+		{"at the limit", QuestionTimeLimit, 1, 100},
+		{"in the grace period", QuestionTimeLimit + Grace/2, 1, 100},
+		{"negative elapsed", -time.Second, 1, 200},
+		{"first of a streak", QuestionTimeLimit, 0, 100},
+		{"second in a row", QuestionTimeLimit, 2, 110},
+		{"streak bonus capped", QuestionTimeLimit, 50, 150},
+		{"speed and streak", 0, 3, 240},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Points(tt.elapsed, tt.streak); got != tt.want {
+				t.Fatalf("Points(%s, %d) = %d, want %d", tt.elapsed, tt.streak, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPointsFasterEarnsMore(t *testing.T) {
+	prev := Points(0, 1)
+	for elapsed := time.Second; elapsed <= QuestionTimeLimit; elapsed += time.Second {
+		got := Points(elapsed, 1)
+		if got > prev {
+			t.Fatalf("Points(%s) = %d, more than %d a second sooner", elapsed, got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestRewards(t *testing.T) {
+	xp, coins := Rewards(1000)
+	if xp != 50 || coins != 25 {
+		t.Fatalf("Rewards(1000) = %d, %d; want 50, 25", xp, coins)
+	}
+	if xp, coins := Rewards(0); xp != 0 || coins != 0 {
+		t.Fatalf("Rewards(0) = %d, %d; want 0, 0", xp, coins)
+	}
+}
@@ -1,50 +1,168 @@
+//go:generate protoc -I ../.. --go_out=. --go_opt=module=github.com/pawfiler/backend/services/quiz --go-grpc_out=. --go-grpc_opt=module=github.com/pawfiler/backend/services/quiz proto/quiz.proto
+
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"database/sql"
 	"log"
-	"math/rand"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+
+	_ "github.com/lib/pq"
+	"github.com/pawfiler/backend/pkg/authz"
+	"github.com/pawfiler/backend/pkg/jwtauth"
+	"github.com/pawfiler/backend/services/quiz/internal/handler"
+	"github.com/pawfiler/backend/services/quiz/internal/repository"
+	"github.com/pawfiler/backend/services/quiz/internal/service"
+	pb "github.com/pawfiler/backend/services/quiz/pb"
+	"github.com/pawfiler/backend/services/quiz/pkg/kafka"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	devJWTSecret                = "dev_jwt_secret_change_in_production"
+	defaultRecentQuestionWindow = 20
 )
 
-type QuizQuestion struct {
-	ID             string   `json:"id"`
-	VideoURL       string   `json:"videoUrl"`
-	ThumbnailEmoji string   `json:"thumbnailEmoji"`
-	Options        []string `json:"options"`
-	CorrectIndex   int      `json:"correctIndex"`
-	Explanation    string   `json:"explanation"`
-	Difficulty     string   `json:"difficulty"`
+func openDB() *sql.DB {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	return db
 }
 
-var questions = []QuizQuestion{
-	{ID: "q1", ThumbnailEmoji: "🎬", Options: []string{"입 모양이 어색해요", "눈 깜빡임이 없어요", "머리카락이 흔들려요", "목소리가 달라요"}, CorrectIndex: 1, Explanation: "딥페이크 영상에서는 눈 깜빡임이 부자연스러운 경우가 많아요!", Difficulty: "easy"},
-	{ID: "q2", ThumbnailEmoji: "🎥", Options: []string{"배경이 자연스러워요", "얼굴 경계가 번져요", "음성이 정확해요", "조명이 일치해요"}, CorrectIndex: 1, Explanation: "얼굴 합성 경계 부분이 번지거나 흐릿한 건 딥페이크의 대표 특징이에요!", Difficulty: "medium"},
+func kafkaBrokers() string {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		log.Println("KAFKA_BROKERS not set, using localhost:9092")
+		brokers = "localhost:9092"
+	}
+	return brokers
 }
 
-func getQuestionHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	json.NewEncoder(w).Encode(questions[rand.Intn(len(questions))])
+// recentQuestionWindow is how many of a player's latest answers
+// GetRandomQuestion avoids repeating, from QUIZ_RECENT_QUESTION_WINDOW.
+func recentQuestionWindow() int {
+	v := os.Getenv("QUIZ_RECENT_QUESTION_WINDOW")
+	if v == "" {
+		return defaultRecentQuestionWindow
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Fatalf("QUIZ_RECENT_QUESTION_WINDOW must be a non-negative number, got %q", v)
+	}
+	return n
 }
 
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-		next(w, r)
+// newVerifier checks access tokens against the auth service's JWKS when
+// JWKS_URL is set, and with the shared JWT_SECRET otherwise.
+func newVerifier() *jwtauth.Verifier {
+	if url := os.Getenv("JWKS_URL"); url != "" {
+		return jwtauth.NewKeySetVerifier(jwtauth.NewRemoteKeySet(url))
+	}
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		log.Println("JWT_SECRET not set, using development secret")
+		secret = devJWTSecret
 	}
+	return jwtauth.NewVerifier([]byte(secret))
 }
 
 func main() {
-	http.HandleFunc("/quiz.QuizService/GetQuestion", corsMiddleware(getQuestionHandler))
+	db := openDB()
+	defer db.Close()
+	brokers := kafkaBrokers()
+	producer := kafka.NewProducer(brokers)
+	defer producer.Close()
+
+	quizRepo := repository.NewQuizRepository(db)
+	quizService := service.NewQuizService(quizRepo, producer, recentQuestionWindow())
+	quizHandler := handler.NewQuizHandler(quizService)
+	// Events queued in the outbox with the change they report, such as
+	// quiz.coin_balance_changed, are published from here.
+	go service.NewOutboxRelay(quizRepo, producer).Run(context.Background())
+
+	// Other services' data about a deleted user goes when auth announces it.
+	consumer := kafka.NewConsumer(brokers, "quiz-service")
+	defer consumer.Close()
+	consumer.Handle("user.deleted", quizService.HandleUserDeleted)
+	go func() {
+		if err := consumer.Run(context.Background()); err != nil {
+			log.Fatalf("event consumer stopped: %v", err)
+		}
+	}()
+
+	verifier := newVerifier()
+	authOpts := []jwtauth.Option{
+		jwtauth.WithPublicMethods(
+			"/grpc.health.v1.Health/",
+			"/grpc.reflection.v1.ServerReflection/",
+			"/grpc.reflection.v1alpha.ServerReflection/",
+		),
+	}
+	// AUTH_REVOKED_SESSIONS_URL rejects tokens from signed-out sessions
+	// straight away instead of when they expire.
+	if url := os.Getenv("AUTH_REVOKED_SESSIONS_URL"); url != "" {
+		authOpts = append(authOpts, jwtauth.WithSessionChecker(jwtauth.NewRevocationList(url)))
+	}
+
+	// Question authoring is limited to roles with quiz.questions.write.
+	authoringMethods := map[string]string{
+		pb.QuizService_CreateQuestion_FullMethodName:  authz.PermissionQuestionsWrite,
+		pb.QuizService_UpdateQuestion_FullMethodName:  authz.PermissionQuestionsWrite,
+		pb.QuizService_ArchiveQuestion_FullMethodName: authz.PermissionQuestionsWrite,
+		pb.QuizService_ListQuestions_FullMethodName:   authz.PermissionQuestionsWrite,
+		pb.QuizService_ImportQuestions_FullMethodName: authz.PermissionQuestionsWrite,
+		pb.QuizService_ExportQuestions_FullMethodName: authz.PermissionQuestionsWrite,
+	}
+
+	grpcServer := grpc.NewServer(
+		// Room for ImportQuestions files, which are larger than gRPC's 4 MB
+		// default.
+		grpc.MaxRecvMsgSize(16<<20),
+		grpc.ChainUnaryInterceptor(
+			jwtauth.UnaryServerInterceptor(verifier, authOpts...),
+			authz.UnaryServerInterceptor(authoringMethods),
+		),
+		grpc.StreamInterceptor(jwtauth.StreamServerInterceptor(verifier, authOpts...)),
+	)
+	pb.RegisterQuizServiceServer(grpcServer, quizHandler)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.QuizService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	// The personal data export endpoint the auth service collects from.
+	mux := http.NewServeMux()
+	mux.Handle("/me/export", jwtauth.Middleware(verifier, authOpts...)(handler.NewExportHandler(quizService)))
+	go func() {
+		log.Println("Quiz service HTTP listening on :8080")
+		if err := http.ListenAndServe(":8080", mux); err != nil {
+			log.Fatalf("failed to serve HTTP: %v", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", ":50052")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
 	log.Println("Quiz service listening on :50052")
-	if err := http.ListenAndServe(":50052", nil); err != nil {
+	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
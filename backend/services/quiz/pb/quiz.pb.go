@@ -0,0 +1,3064 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: proto/quiz.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRandomQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Difficulty    *string                `protobuf:"bytes,2,opt,name=difficulty,proto3,oneof" json:"difficulty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRandomQuestionRequest) Reset() {
+	*x = GetRandomQuestionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRandomQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRandomQuestionRequest) ProtoMessage() {}
+
+func (x *GetRandomQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRandomQuestionRequest.ProtoReflect.Descriptor instead.
+func (*GetRandomQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRandomQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRandomQuestionRequest) GetDifficulty() string {
+	if x != nil && x.Difficulty != nil {
+		return *x.Difficulty
+	}
+	return ""
+}
+
+type GetQuestionByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionByIdRequest) Reset() {
+	*x = GetQuestionByIdRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionByIdRequest) ProtoMessage() {}
+
+func (x *GetQuestionByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionByIdRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetQuestionByIdRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+type GetReviewQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewQuestionRequest) Reset() {
+	*x = GetReviewQuestionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewQuestionRequest) ProtoMessage() {}
+
+func (x *GetReviewQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewQuestionRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{2}
+}
+
+type Question struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VideoUrl         string                 `protobuf:"bytes,2,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	ThumbnailEmoji   string                 `protobuf:"bytes,3,opt,name=thumbnail_emoji,json=thumbnailEmoji,proto3" json:"thumbnail_emoji,omitempty"`
+	Options          []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	Difficulty       string                 `protobuf:"bytes,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	AttemptId        string                 `protobuf:"bytes,6,opt,name=attempt_id,json=attemptId,proto3" json:"attempt_id,omitempty"`
+	AttemptExpiresIn int64                  `protobuf:"varint,7,opt,name=attempt_expires_in,json=attemptExpiresIn,proto3" json:"attempt_expires_in,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	mi := &file_proto_quiz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Question) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Question) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *Question) GetThumbnailEmoji() string {
+	if x != nil {
+		return x.ThumbnailEmoji
+	}
+	return ""
+}
+
+func (x *Question) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Question) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Question) GetAttemptId() string {
+	if x != nil {
+		return x.AttemptId
+	}
+	return ""
+}
+
+func (x *Question) GetAttemptExpiresIn() int64 {
+	if x != nil {
+		return x.AttemptExpiresIn
+	}
+	return 0
+}
+
+type QuizQuestion struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VideoUrl       string                 `protobuf:"bytes,2,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	ThumbnailEmoji string                 `protobuf:"bytes,3,opt,name=thumbnail_emoji,json=thumbnailEmoji,proto3" json:"thumbnail_emoji,omitempty"`
+	Options        []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	CorrectIndex   int32                  `protobuf:"varint,5,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	Explanation    string                 `protobuf:"bytes,6,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	Difficulty     string                 `protobuf:"bytes,7,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status         string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExternalKey    string                 `protobuf:"bytes,13,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"`
+	Rating         float64                `protobuf:"fixed64,14,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatedAnswers   int32                  `protobuf:"varint,15,opt,name=rated_answers,json=ratedAnswers,proto3" json:"rated_answers,omitempty"`
+	Category       string                 `protobuf:"bytes,16,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QuizQuestion) Reset() {
+	*x = QuizQuestion{}
+	mi := &file_proto_quiz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuizQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuizQuestion) ProtoMessage() {}
+
+func (x *QuizQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuizQuestion.ProtoReflect.Descriptor instead.
+func (*QuizQuestion) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QuizQuestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetThumbnailEmoji() string {
+	if x != nil {
+		return x.ThumbnailEmoji
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *QuizQuestion) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+func (x *QuizQuestion) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *QuizQuestion) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+func (x *QuizQuestion) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *QuizQuestion) GetRatedAnswers() int32 {
+	if x != nil {
+		return x.RatedAnswers
+	}
+	return 0
+}
+
+func (x *QuizQuestion) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type CreateQuestionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VideoUrl       string                 `protobuf:"bytes,1,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	ThumbnailEmoji string                 `protobuf:"bytes,2,opt,name=thumbnail_emoji,json=thumbnailEmoji,proto3" json:"thumbnail_emoji,omitempty"`
+	Options        []string               `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	CorrectIndex   int32                  `protobuf:"varint,4,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	Explanation    string                 `protobuf:"bytes,5,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	Difficulty     string                 `protobuf:"bytes,6,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags           []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	ExternalKey    string                 `protobuf:"bytes,8,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"`
+	Category       string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateQuestionRequest) Reset() {
+	*x = CreateQuestionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuestionRequest) ProtoMessage() {}
+
+func (x *CreateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*CreateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateQuestionRequest) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetThumbnailEmoji() string {
+	if x != nil {
+		return x.ThumbnailEmoji
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CreateQuestionRequest) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+func (x *CreateQuestionRequest) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateQuestionRequest) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type UpdateQuestionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId     string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	VideoUrl       string                 `protobuf:"bytes,2,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	ThumbnailEmoji string                 `protobuf:"bytes,3,opt,name=thumbnail_emoji,json=thumbnailEmoji,proto3" json:"thumbnail_emoji,omitempty"`
+	Options        []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	CorrectIndex   int32                  `protobuf:"varint,5,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	Explanation    string                 `protobuf:"bytes,6,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	Difficulty     string                 `protobuf:"bytes,7,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status         string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	ExternalKey    string                 `protobuf:"bytes,10,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"`
+	Category       string                 `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateQuestionRequest) Reset() {
+	*x = UpdateQuestionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuestionRequest) ProtoMessage() {}
+
+func (x *UpdateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetThumbnailEmoji() string {
+	if x != nil {
+		return x.ThumbnailEmoji
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UpdateQuestionRequest) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+func (x *UpdateQuestionRequest) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateQuestionRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ArchiveQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveQuestionRequest) Reset() {
+	*x = ArchiveQuestionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveQuestionRequest) ProtoMessage() {}
+
+func (x *ArchiveQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveQuestionRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ArchiveQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+type ListQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Difficulty    *string                `protobuf:"bytes,1,opt,name=difficulty,proto3,oneof" json:"difficulty,omitempty"`
+	Tag           *string                `protobuf:"bytes,2,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	Status        *string                `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsRequest) Reset() {
+	*x = ListQuestionsRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsRequest) ProtoMessage() {}
+
+func (x *ListQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ListQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListQuestionsRequest) GetDifficulty() string {
+	if x != nil && x.Difficulty != nil {
+		return *x.Difficulty
+	}
+	return ""
+}
+
+func (x *ListQuestionsRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *ListQuestionsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListQuestionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListQuestionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*QuizQuestion        `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsResponse) Reset() {
+	*x = ListQuestionsResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsResponse) ProtoMessage() {}
+
+func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ListQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListQuestionsResponse) GetQuestions() []*QuizQuestion {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *ListQuestionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListQuestionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type SubmitAnswerRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	QuestionId     string                 `protobuf:"bytes,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	SelectedIndex  int32                  `protobuf:"varint,3,opt,name=selected_index,json=selectedIndex,proto3" json:"selected_index,omitempty"`
+	AttemptId      string                 `protobuf:"bytes,4,opt,name=attempt_id,json=attemptId,proto3" json:"attempt_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubmitAnswerRequest) Reset() {
+	*x = SubmitAnswerRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerRequest) ProtoMessage() {}
+
+func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubmitAnswerRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetSelectedIndex() int32 {
+	if x != nil {
+		return x.SelectedIndex
+	}
+	return 0
+}
+
+func (x *SubmitAnswerRequest) GetAttemptId() string {
+	if x != nil {
+		return x.AttemptId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type SubmitAnswerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Correct       bool                   `protobuf:"varint,1,opt,name=correct,proto3" json:"correct,omitempty"`
+	XpEarned      int32                  `protobuf:"varint,2,opt,name=xp_earned,json=xpEarned,proto3" json:"xp_earned,omitempty"`
+	CoinsEarned   int32                  `protobuf:"varint,3,opt,name=coins_earned,json=coinsEarned,proto3" json:"coins_earned,omitempty"`
+	Explanation   string                 `protobuf:"bytes,4,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	StreakCount   int32                  `protobuf:"varint,5,opt,name=streak_count,json=streakCount,proto3" json:"streak_count,omitempty"`
+	CorrectIndex  int32                  `protobuf:"varint,6,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitAnswerResponse) Reset() {
+	*x = SubmitAnswerResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerResponse) ProtoMessage() {}
+
+func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerResponse.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubmitAnswerResponse) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+func (x *SubmitAnswerResponse) GetXpEarned() int32 {
+	if x != nil {
+		return x.XpEarned
+	}
+	return 0
+}
+
+func (x *SubmitAnswerResponse) GetCoinsEarned() int32 {
+	if x != nil {
+		return x.CoinsEarned
+	}
+	return 0
+}
+
+func (x *SubmitAnswerResponse) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *SubmitAnswerResponse) GetStreakCount() int32 {
+	if x != nil {
+		return x.StreakCount
+	}
+	return 0
+}
+
+func (x *SubmitAnswerResponse) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUserStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserStatsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type QuizStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalAnswered int32                  `protobuf:"varint,1,opt,name=total_answered,json=totalAnswered,proto3" json:"total_answered,omitempty"`
+	CorrectRate   float64                `protobuf:"fixed64,2,opt,name=correct_rate,json=correctRate,proto3" json:"correct_rate,omitempty"`
+	CurrentStreak int32                  `protobuf:"varint,3,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	BestStreak    int32                  `protobuf:"varint,4,opt,name=best_streak,json=bestStreak,proto3" json:"best_streak,omitempty"`
+	Lives         int32                  `protobuf:"varint,5,opt,name=lives,proto3" json:"lives,omitempty"`
+	Rating        float64                `protobuf:"fixed64,6,opt,name=rating,proto3" json:"rating,omitempty"`
+	ReviewsDue    int32                  `protobuf:"varint,7,opt,name=reviews_due,json=reviewsDue,proto3" json:"reviews_due,omitempty"`
+	MaxLives      int32                  `protobuf:"varint,8,opt,name=max_lives,json=maxLives,proto3" json:"max_lives,omitempty"`
+	NextLifeIn    int64                  `protobuf:"varint,9,opt,name=next_life_in,json=nextLifeIn,proto3" json:"next_life_in,omitempty"`
+	CorrectCount  int32                  `protobuf:"varint,10,opt,name=correct_count,json=correctCount,proto3" json:"correct_count,omitempty"`
+	ByDifficulty  []*StatsBreakdown      `protobuf:"bytes,11,rep,name=by_difficulty,json=byDifficulty,proto3" json:"by_difficulty,omitempty"`
+	ByCategory    []*StatsBreakdown      `protobuf:"bytes,12,rep,name=by_category,json=byCategory,proto3" json:"by_category,omitempty"`
+	Daily         []*DailyStats          `protobuf:"bytes,13,rep,name=daily,proto3" json:"daily,omitempty"`
+	Coins         int32                  `protobuf:"varint,14,opt,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuizStats) Reset() {
+	*x = QuizStats{}
+	mi := &file_proto_quiz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuizStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuizStats) ProtoMessage() {}
+
+func (x *QuizStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuizStats.ProtoReflect.Descriptor instead.
+func (*QuizStats) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QuizStats) GetTotalAnswered() int32 {
+	if x != nil {
+		return x.TotalAnswered
+	}
+	return 0
+}
+
+func (x *QuizStats) GetCorrectRate() float64 {
+	if x != nil {
+		return x.CorrectRate
+	}
+	return 0
+}
+
+func (x *QuizStats) GetCurrentStreak() int32 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *QuizStats) GetBestStreak() int32 {
+	if x != nil {
+		return x.BestStreak
+	}
+	return 0
+}
+
+func (x *QuizStats) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+func (x *QuizStats) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *QuizStats) GetReviewsDue() int32 {
+	if x != nil {
+		return x.ReviewsDue
+	}
+	return 0
+}
+
+func (x *QuizStats) GetMaxLives() int32 {
+	if x != nil {
+		return x.MaxLives
+	}
+	return 0
+}
+
+func (x *QuizStats) GetNextLifeIn() int64 {
+	if x != nil {
+		return x.NextLifeIn
+	}
+	return 0
+}
+
+func (x *QuizStats) GetCorrectCount() int32 {
+	if x != nil {
+		return x.CorrectCount
+	}
+	return 0
+}
+
+func (x *QuizStats) GetByDifficulty() []*StatsBreakdown {
+	if x != nil {
+		return x.ByDifficulty
+	}
+	return nil
+}
+
+func (x *QuizStats) GetByCategory() []*StatsBreakdown {
+	if x != nil {
+		return x.ByCategory
+	}
+	return nil
+}
+
+func (x *QuizStats) GetDaily() []*DailyStats {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *QuizStats) GetCoins() int32 {
+	if x != nil {
+		return x.Coins
+	}
+	return 0
+}
+
+type StatsBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Answered      int32                  `protobuf:"varint,2,opt,name=answered,proto3" json:"answered,omitempty"`
+	Correct       int32                  `protobuf:"varint,3,opt,name=correct,proto3" json:"correct,omitempty"`
+	CorrectRate   float64                `protobuf:"fixed64,4,opt,name=correct_rate,json=correctRate,proto3" json:"correct_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsBreakdown) Reset() {
+	*x = StatsBreakdown{}
+	mi := &file_proto_quiz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBreakdown) ProtoMessage() {}
+
+func (x *StatsBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsBreakdown.ProtoReflect.Descriptor instead.
+func (*StatsBreakdown) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StatsBreakdown) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StatsBreakdown) GetAnswered() int32 {
+	if x != nil {
+		return x.Answered
+	}
+	return 0
+}
+
+func (x *StatsBreakdown) GetCorrect() int32 {
+	if x != nil {
+		return x.Correct
+	}
+	return 0
+}
+
+func (x *StatsBreakdown) GetCorrectRate() float64 {
+	if x != nil {
+		return x.CorrectRate
+	}
+	return 0
+}
+
+type DailyStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Answered      int32                  `protobuf:"varint,2,opt,name=answered,proto3" json:"answered,omitempty"`
+	Correct       int32                  `protobuf:"varint,3,opt,name=correct,proto3" json:"correct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyStats) Reset() {
+	*x = DailyStats{}
+	mi := &file_proto_quiz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyStats) ProtoMessage() {}
+
+func (x *DailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyStats.ProtoReflect.Descriptor instead.
+func (*DailyStats) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DailyStats) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyStats) GetAnswered() int32 {
+	if x != nil {
+		return x.Answered
+	}
+	return 0
+}
+
+func (x *DailyStats) GetCorrect() int32 {
+	if x != nil {
+		return x.Correct
+	}
+	return 0
+}
+
+type GetAnswerHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnswerHistoryRequest) Reset() {
+	*x = GetAnswerHistoryRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnswerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerHistoryRequest) ProtoMessage() {}
+
+func (x *GetAnswerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAnswerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAnswerHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetAnswerHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type AnswerRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId     string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	ThumbnailEmoji string                 `protobuf:"bytes,2,opt,name=thumbnail_emoji,json=thumbnailEmoji,proto3" json:"thumbnail_emoji,omitempty"`
+	Difficulty     string                 `protobuf:"bytes,3,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Category       string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	SelectedIndex  int32                  `protobuf:"varint,5,opt,name=selected_index,json=selectedIndex,proto3" json:"selected_index,omitempty"`
+	CorrectIndex   int32                  `protobuf:"varint,6,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	Correct        bool                   `protobuf:"varint,7,opt,name=correct,proto3" json:"correct,omitempty"`
+	XpEarned       int32                  `protobuf:"varint,8,opt,name=xp_earned,json=xpEarned,proto3" json:"xp_earned,omitempty"`
+	CoinsEarned    int32                  `protobuf:"varint,9,opt,name=coins_earned,json=coinsEarned,proto3" json:"coins_earned,omitempty"`
+	AnsweredAt     string                 `protobuf:"bytes,10,opt,name=answered_at,json=answeredAt,proto3" json:"answered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnswerRecord) Reset() {
+	*x = AnswerRecord{}
+	mi := &file_proto_quiz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerRecord) ProtoMessage() {}
+
+func (x *AnswerRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerRecord.ProtoReflect.Descriptor instead.
+func (*AnswerRecord) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AnswerRecord) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *AnswerRecord) GetThumbnailEmoji() string {
+	if x != nil {
+		return x.ThumbnailEmoji
+	}
+	return ""
+}
+
+func (x *AnswerRecord) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *AnswerRecord) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AnswerRecord) GetSelectedIndex() int32 {
+	if x != nil {
+		return x.SelectedIndex
+	}
+	return 0
+}
+
+func (x *AnswerRecord) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+func (x *AnswerRecord) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+func (x *AnswerRecord) GetXpEarned() int32 {
+	if x != nil {
+		return x.XpEarned
+	}
+	return 0
+}
+
+func (x *AnswerRecord) GetCoinsEarned() int32 {
+	if x != nil {
+		return x.CoinsEarned
+	}
+	return 0
+}
+
+func (x *AnswerRecord) GetAnsweredAt() string {
+	if x != nil {
+		return x.AnsweredAt
+	}
+	return ""
+}
+
+type GetAnswerHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answers       []*AnswerRecord        `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnswerHistoryResponse) Reset() {
+	*x = GetAnswerHistoryResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnswerHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerHistoryResponse) ProtoMessage() {}
+
+func (x *GetAnswerHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAnswerHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetAnswerHistoryResponse) GetAnswers() []*AnswerRecord {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *GetAnswerHistoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetAnswerHistoryResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type RefillLivesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefillLivesRequest) Reset() {
+	*x = RefillLivesRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefillLivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefillLivesRequest) ProtoMessage() {}
+
+func (x *RefillLivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefillLivesRequest.ProtoReflect.Descriptor instead.
+func (*RefillLivesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{19}
+}
+
+type RefillLivesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lives         int32                  `protobuf:"varint,1,opt,name=lives,proto3" json:"lives,omitempty"`
+	MaxLives      int32                  `protobuf:"varint,2,opt,name=max_lives,json=maxLives,proto3" json:"max_lives,omitempty"`
+	CoinsSpent    int32                  `protobuf:"varint,3,opt,name=coins_spent,json=coinsSpent,proto3" json:"coins_spent,omitempty"`
+	CoinsLeft     int32                  `protobuf:"varint,4,opt,name=coins_left,json=coinsLeft,proto3" json:"coins_left,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefillLivesResponse) Reset() {
+	*x = RefillLivesResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefillLivesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefillLivesResponse) ProtoMessage() {}
+
+func (x *RefillLivesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefillLivesResponse.ProtoReflect.Descriptor instead.
+func (*RefillLivesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RefillLivesResponse) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+func (x *RefillLivesResponse) GetMaxLives() int32 {
+	if x != nil {
+		return x.MaxLives
+	}
+	return 0
+}
+
+func (x *RefillLivesResponse) GetCoinsSpent() int32 {
+	if x != nil {
+		return x.CoinsSpent
+	}
+	return 0
+}
+
+func (x *RefillLivesResponse) GetCoinsLeft() int32 {
+	if x != nil {
+		return x.CoinsLeft
+	}
+	return 0
+}
+
+type StartTimedSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Difficulty    *string                `protobuf:"bytes,1,opt,name=difficulty,proto3,oneof" json:"difficulty,omitempty"`
+	QuestionCount int32                  `protobuf:"varint,2,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTimedSessionRequest) Reset() {
+	*x = StartTimedSessionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTimedSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTimedSessionRequest) ProtoMessage() {}
+
+func (x *StartTimedSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTimedSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartTimedSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StartTimedSessionRequest) GetDifficulty() string {
+	if x != nil && x.Difficulty != nil {
+		return *x.Difficulty
+	}
+	return ""
+}
+
+func (x *StartTimedSessionRequest) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+type TimedSession struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Question       *Question              `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	QuestionNumber int32                  `protobuf:"varint,3,opt,name=question_number,json=questionNumber,proto3" json:"question_number,omitempty"`
+	QuestionCount  int32                  `protobuf:"varint,4,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"`
+	TimeLimitMs    int64                  `protobuf:"varint,5,opt,name=time_limit_ms,json=timeLimitMs,proto3" json:"time_limit_ms,omitempty"`
+	Score          int32                  `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TimedSession) Reset() {
+	*x = TimedSession{}
+	mi := &file_proto_quiz_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimedSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimedSession) ProtoMessage() {}
+
+func (x *TimedSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimedSession.ProtoReflect.Descriptor instead.
+func (*TimedSession) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TimedSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TimedSession) GetQuestion() *Question {
+	if x != nil {
+		return x.Question
+	}
+	return nil
+}
+
+func (x *TimedSession) GetQuestionNumber() int32 {
+	if x != nil {
+		return x.QuestionNumber
+	}
+	return 0
+}
+
+func (x *TimedSession) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+func (x *TimedSession) GetTimeLimitMs() int64 {
+	if x != nil {
+		return x.TimeLimitMs
+	}
+	return 0
+}
+
+func (x *TimedSession) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SubmitTimedAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	QuestionId    string                 `protobuf:"bytes,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	SelectedIndex int32                  `protobuf:"varint,3,opt,name=selected_index,json=selectedIndex,proto3" json:"selected_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTimedAnswerRequest) Reset() {
+	*x = SubmitTimedAnswerRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTimedAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTimedAnswerRequest) ProtoMessage() {}
+
+func (x *SubmitTimedAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTimedAnswerRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTimedAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubmitTimedAnswerRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SubmitTimedAnswerRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *SubmitTimedAnswerRequest) GetSelectedIndex() int32 {
+	if x != nil {
+		return x.SelectedIndex
+	}
+	return 0
+}
+
+type TimedAnswerResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Correct       bool                   `protobuf:"varint,1,opt,name=correct,proto3" json:"correct,omitempty"`
+	TimedOut      bool                   `protobuf:"varint,2,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	CorrectIndex  int32                  `protobuf:"varint,3,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	Explanation   string                 `protobuf:"bytes,4,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	ElapsedMs     int64                  `protobuf:"varint,5,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Points        int32                  `protobuf:"varint,6,opt,name=points,proto3" json:"points,omitempty"`
+	Streak        int32                  `protobuf:"varint,7,opt,name=streak,proto3" json:"streak,omitempty"`
+	Score         int32                  `protobuf:"varint,8,opt,name=score,proto3" json:"score,omitempty"`
+	Next          *TimedSession          `protobuf:"bytes,9,opt,name=next,proto3" json:"next,omitempty"`
+	Result        *TimedSessionResult    `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimedAnswerResult) Reset() {
+	*x = TimedAnswerResult{}
+	mi := &file_proto_quiz_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimedAnswerResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimedAnswerResult) ProtoMessage() {}
+
+func (x *TimedAnswerResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimedAnswerResult.ProtoReflect.Descriptor instead.
+func (*TimedAnswerResult) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TimedAnswerResult) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+func (x *TimedAnswerResult) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *TimedAnswerResult) GetCorrectIndex() int32 {
+	if x != nil {
+		return x.CorrectIndex
+	}
+	return 0
+}
+
+func (x *TimedAnswerResult) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *TimedAnswerResult) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *TimedAnswerResult) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *TimedAnswerResult) GetStreak() int32 {
+	if x != nil {
+		return x.Streak
+	}
+	return 0
+}
+
+func (x *TimedAnswerResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TimedAnswerResult) GetNext() *TimedSession {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+func (x *TimedAnswerResult) GetResult() *TimedSessionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type FinishTimedSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishTimedSessionRequest) Reset() {
+	*x = FinishTimedSessionRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishTimedSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishTimedSessionRequest) ProtoMessage() {}
+
+func (x *FinishTimedSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishTimedSessionRequest.ProtoReflect.Descriptor instead.
+func (*FinishTimedSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FinishTimedSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type TimedSessionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	QuestionCount int32                  `protobuf:"varint,3,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"`
+	AnsweredCount int32                  `protobuf:"varint,4,opt,name=answered_count,json=answeredCount,proto3" json:"answered_count,omitempty"`
+	CorrectCount  int32                  `protobuf:"varint,5,opt,name=correct_count,json=correctCount,proto3" json:"correct_count,omitempty"`
+	BestStreak    int32                  `protobuf:"varint,6,opt,name=best_streak,json=bestStreak,proto3" json:"best_streak,omitempty"`
+	XpEarned      int32                  `protobuf:"varint,7,opt,name=xp_earned,json=xpEarned,proto3" json:"xp_earned,omitempty"`
+	CoinsEarned   int32                  `protobuf:"varint,8,opt,name=coins_earned,json=coinsEarned,proto3" json:"coins_earned,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimedSessionResult) Reset() {
+	*x = TimedSessionResult{}
+	mi := &file_proto_quiz_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimedSessionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimedSessionResult) ProtoMessage() {}
+
+func (x *TimedSessionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimedSessionResult.ProtoReflect.Descriptor instead.
+func (*TimedSessionResult) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TimedSessionResult) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TimedSessionResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetAnsweredCount() int32 {
+	if x != nil {
+		return x.AnsweredCount
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetCorrectCount() int32 {
+	if x != nil {
+		return x.CorrectCount
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetBestStreak() int32 {
+	if x != nil {
+		return x.BestStreak
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetXpEarned() int32 {
+	if x != nil {
+		return x.XpEarned
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetCoinsEarned() int32 {
+	if x != nil {
+		return x.CoinsEarned
+	}
+	return 0
+}
+
+func (x *TimedSessionResult) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *TimedSessionResult) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type GetTimedLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTimedLeaderboardRequest) Reset() {
+	*x = GetTimedLeaderboardRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTimedLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimedLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetTimedLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimedLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetTimedLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTimedLeaderboardRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetTimedLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TimedLeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	CorrectCount  int32                  `protobuf:"varint,4,opt,name=correct_count,json=correctCount,proto3" json:"correct_count,omitempty"`
+	QuestionCount int32                  `protobuf:"varint,5,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimedLeaderboardEntry) Reset() {
+	*x = TimedLeaderboardEntry{}
+	mi := &file_proto_quiz_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimedLeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimedLeaderboardEntry) ProtoMessage() {}
+
+func (x *TimedLeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimedLeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*TimedLeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TimedLeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TimedLeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TimedLeaderboardEntry) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TimedLeaderboardEntry) GetCorrectCount() int32 {
+	if x != nil {
+		return x.CorrectCount
+	}
+	return 0
+}
+
+func (x *TimedLeaderboardEntry) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+func (x *TimedLeaderboardEntry) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type TimedLeaderboard struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Period        string                   `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Entries       []*TimedLeaderboardEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Me            *TimedLeaderboardEntry   `protobuf:"bytes,3,opt,name=me,proto3" json:"me,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimedLeaderboard) Reset() {
+	*x = TimedLeaderboard{}
+	mi := &file_proto_quiz_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimedLeaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimedLeaderboard) ProtoMessage() {}
+
+func (x *TimedLeaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimedLeaderboard.ProtoReflect.Descriptor instead.
+func (*TimedLeaderboard) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TimedLeaderboard) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *TimedLeaderboard) GetEntries() []*TimedLeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *TimedLeaderboard) GetMe() *TimedLeaderboardEntry {
+	if x != nil {
+		return x.Me
+	}
+	return nil
+}
+
+type ImportQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportQuestionsRequest) Reset() {
+	*x = ImportQuestionsRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportQuestionsRequest) ProtoMessage() {}
+
+func (x *ImportQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ImportQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportQuestionsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportQuestionsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportQuestionsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	ExternalKey   string                 `protobuf:"bytes,2,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_proto_quiz_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportRowError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Applied       bool                   `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportQuestionsResponse) Reset() {
+	*x = ImportQuestionsResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportQuestionsResponse) ProtoMessage() {}
+
+func (x *ImportQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ImportQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportQuestionsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportQuestionsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportQuestionsResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportQuestionsResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ImportQuestionsResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+type ExportQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Difficulty    *string                `protobuf:"bytes,2,opt,name=difficulty,proto3,oneof" json:"difficulty,omitempty"`
+	Tag           *string                `protobuf:"bytes,3,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportQuestionsRequest) Reset() {
+	*x = ExportQuestionsRequest{}
+	mi := &file_proto_quiz_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportQuestionsRequest) ProtoMessage() {}
+
+func (x *ExportQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportQuestionsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportQuestionsRequest) GetDifficulty() string {
+	if x != nil && x.Difficulty != nil {
+		return *x.Difficulty
+	}
+	return ""
+}
+
+func (x *ExportQuestionsRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *ExportQuestionsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+type ExportQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportQuestionsResponse) Reset() {
+	*x = ExportQuestionsResponse{}
+	mi := &file_proto_quiz_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportQuestionsResponse) ProtoMessage() {}
+
+func (x *ExportQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_quiz_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ExportQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_quiz_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportQuestionsResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportQuestionsResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportQuestionsResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportQuestionsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_proto_quiz_proto protoreflect.FileDescriptor
+
+const file_proto_quiz_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/quiz.proto\x12\x04quiz\"g\n" +
+	"\x18GetRandomQuestionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\tH\x00R\n" +
+	"difficulty\x88\x01\x01B\r\n" +
+	"\v_difficulty\"9\n" +
+	"\x16GetQuestionByIdRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\"\x1a\n" +
+	"\x18GetReviewQuestionRequest\"\xe7\x01\n" +
+	"\bQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tvideo_url\x18\x02 \x01(\tR\bvideoUrl\x12'\n" +
+	"\x0fthumbnail_emoji\x18\x03 \x01(\tR\x0ethumbnailEmoji\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x05 \x01(\tR\n" +
+	"difficulty\x12\x1d\n" +
+	"\n" +
+	"attempt_id\x18\x06 \x01(\tR\tattemptId\x12,\n" +
+	"\x12attempt_expires_in\x18\a \x01(\x03R\x10attemptExpiresIn\"\xea\x03\n" +
+	"\fQuizQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tvideo_url\x18\x02 \x01(\tR\bvideoUrl\x12'\n" +
+	"\x0fthumbnail_emoji\x18\x03 \x01(\tR\x0ethumbnailEmoji\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12#\n" +
+	"\rcorrect_index\x18\x05 \x01(\x05R\fcorrectIndex\x12 \n" +
+	"\vexplanation\x18\x06 \x01(\tR\vexplanation\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\a \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\x12!\n" +
+	"\fexternal_key\x18\r \x01(\tR\vexternalKey\x12\x16\n" +
+	"\x06rating\x18\x0e \x01(\x01R\x06rating\x12#\n" +
+	"\rrated_answers\x18\x0f \x01(\x05R\fratedAnswers\x12\x1a\n" +
+	"\bcategory\x18\x10 \x01(\tR\bcategory\"\xb1\x02\n" +
+	"\x15CreateQuestionRequest\x12\x1b\n" +
+	"\tvideo_url\x18\x01 \x01(\tR\bvideoUrl\x12'\n" +
+	"\x0fthumbnail_emoji\x18\x02 \x01(\tR\x0ethumbnailEmoji\x12\x18\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\x12#\n" +
+	"\rcorrect_index\x18\x04 \x01(\x05R\fcorrectIndex\x12 \n" +
+	"\vexplanation\x18\x05 \x01(\tR\vexplanation\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x06 \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12!\n" +
+	"\fexternal_key\x18\b \x01(\tR\vexternalKey\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\"\xea\x02\n" +
+	"\x15UpdateQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x1b\n" +
+	"\tvideo_url\x18\x02 \x01(\tR\bvideoUrl\x12'\n" +
+	"\x0fthumbnail_emoji\x18\x03 \x01(\tR\x0ethumbnailEmoji\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12#\n" +
+	"\rcorrect_index\x18\x05 \x01(\x05R\fcorrectIndex\x12 \n" +
+	"\vexplanation\x18\x06 \x01(\tR\vexplanation\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\a \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12!\n" +
+	"\fexternal_key\x18\n" +
+	" \x01(\tR\vexternalKey\x12\x1a\n" +
+	"\bcategory\x18\v \x01(\tR\bcategory\"9\n" +
+	"\x16ArchiveQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\"\xc2\x01\n" +
+	"\x14ListQuestionsRequest\x12#\n" +
+	"\n" +
+	"difficulty\x18\x01 \x01(\tH\x00R\n" +
+	"difficulty\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\x02 \x01(\tH\x01R\x03tag\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x03 \x01(\tH\x02R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSizeB\r\n" +
+	"\v_difficultyB\x06\n" +
+	"\x04_tagB\t\n" +
+	"\a_status\"~\n" +
+	"\x15ListQuestionsResponse\x120\n" +
+	"\tquestions\x18\x01 \x03(\v2\x12.quiz.QuizQuestionR\tquestions\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\"\xbe\x01\n" +
+	"\x13SubmitAnswerRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\tR\n" +
+	"questionId\x12%\n" +
+	"\x0eselected_index\x18\x03 \x01(\x05R\rselectedIndex\x12\x1d\n" +
+	"\n" +
+	"attempt_id\x18\x04 \x01(\tR\tattemptId\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\xda\x01\n" +
+	"\x14SubmitAnswerResponse\x12\x18\n" +
+	"\acorrect\x18\x01 \x01(\bR\acorrect\x12\x1b\n" +
+	"\txp_earned\x18\x02 \x01(\x05R\bxpEarned\x12!\n" +
+	"\fcoins_earned\x18\x03 \x01(\x05R\vcoinsEarned\x12 \n" +
+	"\vexplanation\x18\x04 \x01(\tR\vexplanation\x12!\n" +
+	"\fstreak_count\x18\x05 \x01(\x05R\vstreakCount\x12#\n" +
+	"\rcorrect_index\x18\x06 \x01(\x05R\fcorrectIndex\"B\n" +
+	"\x13GetUserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\x80\x04\n" +
+	"\tQuizStats\x12%\n" +
+	"\x0etotal_answered\x18\x01 \x01(\x05R\rtotalAnswered\x12!\n" +
+	"\fcorrect_rate\x18\x02 \x01(\x01R\vcorrectRate\x12%\n" +
+	"\x0ecurrent_streak\x18\x03 \x01(\x05R\rcurrentStreak\x12\x1f\n" +
+	"\vbest_streak\x18\x04 \x01(\x05R\n" +
+	"bestStreak\x12\x14\n" +
+	"\x05lives\x18\x05 \x01(\x05R\x05lives\x12\x16\n" +
+	"\x06rating\x18\x06 \x01(\x01R\x06rating\x12\x1f\n" +
+	"\vreviews_due\x18\a \x01(\x05R\n" +
+	"reviewsDue\x12\x1b\n" +
+	"\tmax_lives\x18\b \x01(\x05R\bmaxLives\x12 \n" +
+	"\fnext_life_in\x18\t \x01(\x03R\n" +
+	"nextLifeIn\x12#\n" +
+	"\rcorrect_count\x18\n" +
+	" \x01(\x05R\fcorrectCount\x129\n" +
+	"\rby_difficulty\x18\v \x03(\v2\x14.quiz.StatsBreakdownR\fbyDifficulty\x125\n" +
+	"\vby_category\x18\f \x03(\v2\x14.quiz.StatsBreakdownR\n" +
+	"byCategory\x12&\n" +
+	"\x05daily\x18\r \x03(\v2\x10.quiz.DailyStatsR\x05daily\x12\x14\n" +
+	"\x05coins\x18\x0e \x01(\x05R\x05coins\"{\n" +
+	"\x0eStatsBreakdown\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\banswered\x18\x02 \x01(\x05R\banswered\x12\x18\n" +
+	"\acorrect\x18\x03 \x01(\x05R\acorrect\x12!\n" +
+	"\fcorrect_rate\x18\x04 \x01(\x01R\vcorrectRate\"V\n" +
+	"\n" +
+	"DailyStats\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1a\n" +
+	"\banswered\x18\x02 \x01(\x05R\banswered\x12\x18\n" +
+	"\acorrect\x18\x03 \x01(\x05R\acorrect\"J\n" +
+	"\x17GetAnswerHistoryRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\xdb\x02\n" +
+	"\fAnswerRecord\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12'\n" +
+	"\x0fthumbnail_emoji\x18\x02 \x01(\tR\x0ethumbnailEmoji\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x03 \x01(\tR\n" +
+	"difficulty\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12%\n" +
+	"\x0eselected_index\x18\x05 \x01(\x05R\rselectedIndex\x12#\n" +
+	"\rcorrect_index\x18\x06 \x01(\x05R\fcorrectIndex\x12\x18\n" +
+	"\acorrect\x18\a \x01(\bR\acorrect\x12\x1b\n" +
+	"\txp_earned\x18\b \x01(\x05R\bxpEarned\x12!\n" +
+	"\fcoins_earned\x18\t \x01(\x05R\vcoinsEarned\x12\x1f\n" +
+	"\vanswered_at\x18\n" +
+	" \x01(\tR\n" +
+	"answeredAt\"}\n" +
+	"\x18GetAnswerHistoryResponse\x12,\n" +
+	"\aanswers\x18\x01 \x03(\v2\x12.quiz.AnswerRecordR\aanswers\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\"\x14\n" +
+	"\x12RefillLivesRequest\"\x88\x01\n" +
+	"\x13RefillLivesResponse\x12\x14\n" +
+	"\x05lives\x18\x01 \x01(\x05R\x05lives\x12\x1b\n" +
+	"\tmax_lives\x18\x02 \x01(\x05R\bmaxLives\x12\x1f\n" +
+	"\vcoins_spent\x18\x03 \x01(\x05R\n" +
+	"coinsSpent\x12\x1d\n" +
+	"\n" +
+	"coins_left\x18\x04 \x01(\x05R\tcoinsLeft\"u\n" +
+	"\x18StartTimedSessionRequest\x12#\n" +
+	"\n" +
+	"difficulty\x18\x01 \x01(\tH\x00R\n" +
+	"difficulty\x88\x01\x01\x12%\n" +
+	"\x0equestion_count\x18\x02 \x01(\x05R\rquestionCountB\r\n" +
+	"\v_difficulty\"\xe3\x01\n" +
+	"\fTimedSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12*\n" +
+	"\bquestion\x18\x02 \x01(\v2\x0e.quiz.QuestionR\bquestion\x12'\n" +
+	"\x0fquestion_number\x18\x03 \x01(\x05R\x0equestionNumber\x12%\n" +
+	"\x0equestion_count\x18\x04 \x01(\x05R\rquestionCount\x12\"\n" +
+	"\rtime_limit_ms\x18\x05 \x01(\x03R\vtimeLimitMs\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x05R\x05score\"\x81\x01\n" +
+	"\x18SubmitTimedAnswerRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\tR\n" +
+	"questionId\x12%\n" +
+	"\x0eselected_index\x18\x03 \x01(\x05R\rselectedIndex\"\xd0\x02\n" +
+	"\x11TimedAnswerResult\x12\x18\n" +
+	"\acorrect\x18\x01 \x01(\bR\acorrect\x12\x1b\n" +
+	"\ttimed_out\x18\x02 \x01(\bR\btimedOut\x12#\n" +
+	"\rcorrect_index\x18\x03 \x01(\x05R\fcorrectIndex\x12 \n" +
+	"\vexplanation\x18\x04 \x01(\tR\vexplanation\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x05 \x01(\x03R\telapsedMs\x12\x16\n" +
+	"\x06points\x18\x06 \x01(\x05R\x06points\x12\x16\n" +
+	"\x06streak\x18\a \x01(\x05R\x06streak\x12\x14\n" +
+	"\x05score\x18\b \x01(\x05R\x05score\x12&\n" +
+	"\x04next\x18\t \x01(\v2\x12.quiz.TimedSessionR\x04next\x120\n" +
+	"\x06result\x18\n" +
+	" \x01(\v2\x18.quiz.TimedSessionResultR\x06result\":\n" +
+	"\x19FinishTimedSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xdd\x02\n" +
+	"\x12TimedSessionResult\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12%\n" +
+	"\x0equestion_count\x18\x03 \x01(\x05R\rquestionCount\x12%\n" +
+	"\x0eanswered_count\x18\x04 \x01(\x05R\ransweredCount\x12#\n" +
+	"\rcorrect_count\x18\x05 \x01(\x05R\fcorrectCount\x12\x1f\n" +
+	"\vbest_streak\x18\x06 \x01(\x05R\n" +
+	"bestStreak\x12\x1b\n" +
+	"\txp_earned\x18\a \x01(\x05R\bxpEarned\x12!\n" +
+	"\fcoins_earned\x18\b \x01(\x05R\vcoinsEarned\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\tR\n" +
+	"finishedAt\"J\n" +
+	"\x1aGetTimedLeaderboardRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xc7\x01\n" +
+	"\x15TimedLeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12#\n" +
+	"\rcorrect_count\x18\x04 \x01(\x05R\fcorrectCount\x12%\n" +
+	"\x0equestion_count\x18\x05 \x01(\x05R\rquestionCount\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\tR\n" +
+	"finishedAt\"\x8e\x01\n" +
+	"\x10TimedLeaderboard\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x125\n" +
+	"\aentries\x18\x02 \x03(\v2\x1b.quiz.TimedLeaderboardEntryR\aentries\x12+\n" +
+	"\x02me\x18\x03 \x01(\v2\x1b.quiz.TimedLeaderboardEntryR\x02me\"]\n" +
+	"\x16ImportQuestionsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"_\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12!\n" +
+	"\fexternal_key\x18\x02 \x01(\tR\vexternalKey\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xb3\x01\n" +
+	"\x17ImportQuestionsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12,\n" +
+	"\x06errors\x18\x03 \x03(\v2\x14.quiz.ImportRowErrorR\x06errors\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12\x1c\n" +
+	"\tunchanged\x18\x05 \x01(\x05R\tunchanged\"\xab\x01\n" +
+	"\x16ExportQuestionsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12#\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\tH\x00R\n" +
+	"difficulty\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\x03 \x01(\tH\x01R\x03tag\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x04 \x01(\tH\x02R\x06status\x88\x01\x01B\r\n" +
+	"\v_difficultyB\x06\n" +
+	"\x04_tagB\t\n" +
+	"\a_status\"\x82\x01\n" +
+	"\x17ExportQuestionsResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count2\xe0\t\n" +
+	"\vQuizService\x12C\n" +
+	"\x11GetRandomQuestion\x12\x1e.quiz.GetRandomQuestionRequest\x1a\x0e.quiz.Question\x12E\n" +
+	"\fSubmitAnswer\x12\x19.quiz.SubmitAnswerRequest\x1a\x1a.quiz.SubmitAnswerResponse\x12:\n" +
+	"\fGetUserStats\x12\x19.quiz.GetUserStatsRequest\x1a\x0f.quiz.QuizStats\x12Q\n" +
+	"\x10GetAnswerHistory\x12\x1d.quiz.GetAnswerHistoryRequest\x1a\x1e.quiz.GetAnswerHistoryResponse\x12?\n" +
+	"\x0fGetQuestionById\x12\x1c.quiz.GetQuestionByIdRequest\x1a\x0e.quiz.Question\x12C\n" +
+	"\x11GetReviewQuestion\x12\x1e.quiz.GetReviewQuestionRequest\x1a\x0e.quiz.Question\x12B\n" +
+	"\vRefillLives\x12\x18.quiz.RefillLivesRequest\x1a\x19.quiz.RefillLivesResponse\x12G\n" +
+	"\x11StartTimedSession\x12\x1e.quiz.StartTimedSessionRequest\x1a\x12.quiz.TimedSession\x12L\n" +
+	"\x11SubmitTimedAnswer\x12\x1e.quiz.SubmitTimedAnswerRequest\x1a\x17.quiz.TimedAnswerResult\x12O\n" +
+	"\x12FinishTimedSession\x12\x1f.quiz.FinishTimedSessionRequest\x1a\x18.quiz.TimedSessionResult\x12O\n" +
+	"\x13GetTimedLeaderboard\x12 .quiz.GetTimedLeaderboardRequest\x1a\x16.quiz.TimedLeaderboard\x12A\n" +
+	"\x0eCreateQuestion\x12\x1b.quiz.CreateQuestionRequest\x1a\x12.quiz.QuizQuestion\x12A\n" +
+	"\x0eUpdateQuestion\x12\x1b.quiz.UpdateQuestionRequest\x1a\x12.quiz.QuizQuestion\x12C\n" +
+	"\x0fArchiveQuestion\x12\x1c.quiz.ArchiveQuestionRequest\x1a\x12.quiz.QuizQuestion\x12H\n" +
+	"\rListQuestions\x12\x1a.quiz.ListQuestionsRequest\x1a\x1b.quiz.ListQuestionsResponse\x12N\n" +
+	"\x0fImportQuestions\x12\x1c.quiz.ImportQuestionsRequest\x1a\x1d.quiz.ImportQuestionsResponse\x12N\n" +
+	"\x0fExportQuestions\x12\x1c.quiz.ExportQuestionsRequest\x1a\x1d.quiz.ExportQuestionsResponseB.Z,github.com/pawfiler/backend/services/quiz/pbb\x06proto3"
+
+var (
+	file_proto_quiz_proto_rawDescOnce sync.Once
+	file_proto_quiz_proto_rawDescData []byte
+)
+
+func file_proto_quiz_proto_rawDescGZIP() []byte {
+	file_proto_quiz_proto_rawDescOnce.Do(func() {
+		file_proto_quiz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_quiz_proto_rawDesc), len(file_proto_quiz_proto_rawDesc)))
+	})
+	return file_proto_quiz_proto_rawDescData
+}
+
+var file_proto_quiz_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_proto_quiz_proto_goTypes = []any{
+	(*GetRandomQuestionRequest)(nil),   // 0: quiz.GetRandomQuestionRequest
+	(*GetQuestionByIdRequest)(nil),     // 1: quiz.GetQuestionByIdRequest
+	(*GetReviewQuestionRequest)(nil),   // 2: quiz.GetReviewQuestionRequest
+	(*Question)(nil),                   // 3: quiz.Question
+	(*QuizQuestion)(nil),               // 4: quiz.QuizQuestion
+	(*CreateQuestionRequest)(nil),      // 5: quiz.CreateQuestionRequest
+	(*UpdateQuestionRequest)(nil),      // 6: quiz.UpdateQuestionRequest
+	(*ArchiveQuestionRequest)(nil),     // 7: quiz.ArchiveQuestionRequest
+	(*ListQuestionsRequest)(nil),       // 8: quiz.ListQuestionsRequest
+	(*ListQuestionsResponse)(nil),      // 9: quiz.ListQuestionsResponse
+	(*SubmitAnswerRequest)(nil),        // 10: quiz.SubmitAnswerRequest
+	(*SubmitAnswerResponse)(nil),       // 11: quiz.SubmitAnswerResponse
+	(*GetUserStatsRequest)(nil),        // 12: quiz.GetUserStatsRequest
+	(*QuizStats)(nil),                  // 13: quiz.QuizStats
+	(*StatsBreakdown)(nil),             // 14: quiz.StatsBreakdown
+	(*DailyStats)(nil),                 // 15: quiz.DailyStats
+	(*GetAnswerHistoryRequest)(nil),    // 16: quiz.GetAnswerHistoryRequest
+	(*AnswerRecord)(nil),               // 17: quiz.AnswerRecord
+	(*GetAnswerHistoryResponse)(nil),   // 18: quiz.GetAnswerHistoryResponse
+	(*RefillLivesRequest)(nil),         // 19: quiz.RefillLivesRequest
+	(*RefillLivesResponse)(nil),        // 20: quiz.RefillLivesResponse
+	(*StartTimedSessionRequest)(nil),   // 21: quiz.StartTimedSessionRequest
+	(*TimedSession)(nil),               // 22: quiz.TimedSession
+	(*SubmitTimedAnswerRequest)(nil),   // 23: quiz.SubmitTimedAnswerRequest
+	(*TimedAnswerResult)(nil),          // 24: quiz.TimedAnswerResult
+	(*FinishTimedSessionRequest)(nil),  // 25: quiz.FinishTimedSessionRequest
+	(*TimedSessionResult)(nil),         // 26: quiz.TimedSessionResult
+	(*GetTimedLeaderboardRequest)(nil), // 27: quiz.GetTimedLeaderboardRequest
+	(*TimedLeaderboardEntry)(nil),      // 28: quiz.TimedLeaderboardEntry
+	(*TimedLeaderboard)(nil),           // 29: quiz.TimedLeaderboard
+	(*ImportQuestionsRequest)(nil),     // 30: quiz.ImportQuestionsRequest
+	(*ImportRowError)(nil),             // 31: quiz.ImportRowError
+	(*ImportQuestionsResponse)(nil),    // 32: quiz.ImportQuestionsResponse
+	(*ExportQuestionsRequest)(nil),     // 33: quiz.ExportQuestionsRequest
+	(*ExportQuestionsResponse)(nil),    // 34: quiz.ExportQuestionsResponse
+}
+var file_proto_quiz_proto_depIdxs = []int32{
+	4,  // 0: quiz.ListQuestionsResponse.questions:type_name -> quiz.QuizQuestion
+	14, // 1: quiz.QuizStats.by_difficulty:type_name -> quiz.StatsBreakdown
+	14, // 2: quiz.QuizStats.by_category:type_name -> quiz.StatsBreakdown
+	15, // 3: quiz.QuizStats.daily:type_name -> quiz.DailyStats
+	17, // 4: quiz.GetAnswerHistoryResponse.answers:type_name -> quiz.AnswerRecord
+	3,  // 5: quiz.TimedSession.question:type_name -> quiz.Question
+	22, // 6: quiz.TimedAnswerResult.next:type_name -> quiz.TimedSession
+	26, // 7: quiz.TimedAnswerResult.result:type_name -> quiz.TimedSessionResult
+	28, // 8: quiz.TimedLeaderboard.entries:type_name -> quiz.TimedLeaderboardEntry
+	28, // 9: quiz.TimedLeaderboard.me:type_name -> quiz.TimedLeaderboardEntry
+	31, // 10: quiz.ImportQuestionsResponse.errors:type_name -> quiz.ImportRowError
+	0,  // 11: quiz.QuizService.GetRandomQuestion:input_type -> quiz.GetRandomQuestionRequest
+	10, // 12: quiz.QuizService.SubmitAnswer:input_type -> quiz.SubmitAnswerRequest
+	12, // 13: quiz.QuizService.GetUserStats:input_type -> quiz.GetUserStatsRequest
+	16, // 14: quiz.QuizService.GetAnswerHistory:input_type -> quiz.GetAnswerHistoryRequest
+	1,  // 15: quiz.QuizService.GetQuestionById:input_type -> quiz.GetQuestionByIdRequest
+	2,  // 16: quiz.QuizService.GetReviewQuestion:input_type -> quiz.GetReviewQuestionRequest
+	19, // 17: quiz.QuizService.RefillLives:input_type -> quiz.RefillLivesRequest
+	21, // 18: quiz.QuizService.StartTimedSession:input_type -> quiz.StartTimedSessionRequest
+	23, // 19: quiz.QuizService.SubmitTimedAnswer:input_type -> quiz.SubmitTimedAnswerRequest
+	25, // 20: quiz.QuizService.FinishTimedSession:input_type -> quiz.FinishTimedSessionRequest
+	27, // 21: quiz.QuizService.GetTimedLeaderboard:input_type -> quiz.GetTimedLeaderboardRequest
+	5,  // 22: quiz.QuizService.CreateQuestion:input_type -> quiz.CreateQuestionRequest
+	6,  // 23: quiz.QuizService.UpdateQuestion:input_type -> quiz.UpdateQuestionRequest
+	7,  // 24: quiz.QuizService.ArchiveQuestion:input_type -> quiz.ArchiveQuestionRequest
+	8,  // 25: quiz.QuizService.ListQuestions:input_type -> quiz.ListQuestionsRequest
+	30, // 26: quiz.QuizService.ImportQuestions:input_type -> quiz.ImportQuestionsRequest
+	33, // 27: quiz.QuizService.ExportQuestions:input_type -> quiz.ExportQuestionsRequest
+	3,  // 28: quiz.QuizService.GetRandomQuestion:output_type -> quiz.Question
+	11, // 29: quiz.QuizService.SubmitAnswer:output_type -> quiz.SubmitAnswerResponse
+	13, // 30: quiz.QuizService.GetUserStats:output_type -> quiz.QuizStats
+	18, // 31: quiz.QuizService.GetAnswerHistory:output_type -> quiz.GetAnswerHistoryResponse
+	3,  // 32: quiz.QuizService.GetQuestionById:output_type -> quiz.Question
+	3,  // 33: quiz.QuizService.GetReviewQuestion:output_type -> quiz.Question
+	20, // 34: quiz.QuizService.RefillLives:output_type -> quiz.RefillLivesResponse
+	22, // 35: quiz.QuizService.StartTimedSession:output_type -> quiz.TimedSession
+	24, // 36: quiz.QuizService.SubmitTimedAnswer:output_type -> quiz.TimedAnswerResult
+	26, // 37: quiz.QuizService.FinishTimedSession:output_type -> quiz.TimedSessionResult
+	29, // 38: quiz.QuizService.GetTimedLeaderboard:output_type -> quiz.TimedLeaderboard
+	4,  // 39: quiz.QuizService.CreateQuestion:output_type -> quiz.QuizQuestion
+	4,  // 40: quiz.QuizService.UpdateQuestion:output_type -> quiz.QuizQuestion
+	4,  // 41: quiz.QuizService.ArchiveQuestion:output_type -> quiz.QuizQuestion
+	9,  // 42: quiz.QuizService.ListQuestions:output_type -> quiz.ListQuestionsResponse
+	32, // 43: quiz.QuizService.ImportQuestions:output_type -> quiz.ImportQuestionsResponse
+	34, // 44: quiz.QuizService.ExportQuestions:output_type -> quiz.ExportQuestionsResponse
+	28, // [28:45] is the sub-list for method output_type
+	11, // [11:28] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_proto_quiz_proto_init() }
+func file_proto_quiz_proto_init() {
+	if File_proto_quiz_proto != nil {
+		return
+	}
+	file_proto_quiz_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_quiz_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_quiz_proto_msgTypes[21].OneofWrappers = []any{}
+	file_proto_quiz_proto_msgTypes[33].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_quiz_proto_rawDesc), len(file_proto_quiz_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_quiz_proto_goTypes,
+		DependencyIndexes: file_proto_quiz_proto_depIdxs,
+		MessageInfos:      file_proto_quiz_proto_msgTypes,
+	}.Build()
+	File_proto_quiz_proto = out.File
+	file_proto_quiz_proto_goTypes = nil
+	file_proto_quiz_proto_depIdxs = nil
+}
@@ -0,0 +1,729 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v3.21.12
+// source: proto/quiz.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuizService_GetRandomQuestion_FullMethodName   = "/quiz.QuizService/GetRandomQuestion"
+	QuizService_SubmitAnswer_FullMethodName        = "/quiz.QuizService/SubmitAnswer"
+	QuizService_GetUserStats_FullMethodName        = "/quiz.QuizService/GetUserStats"
+	QuizService_GetAnswerHistory_FullMethodName    = "/quiz.QuizService/GetAnswerHistory"
+	QuizService_GetQuestionById_FullMethodName     = "/quiz.QuizService/GetQuestionById"
+	QuizService_GetReviewQuestion_FullMethodName   = "/quiz.QuizService/GetReviewQuestion"
+	QuizService_RefillLives_FullMethodName         = "/quiz.QuizService/RefillLives"
+	QuizService_StartTimedSession_FullMethodName   = "/quiz.QuizService/StartTimedSession"
+	QuizService_SubmitTimedAnswer_FullMethodName   = "/quiz.QuizService/SubmitTimedAnswer"
+	QuizService_FinishTimedSession_FullMethodName  = "/quiz.QuizService/FinishTimedSession"
+	QuizService_GetTimedLeaderboard_FullMethodName = "/quiz.QuizService/GetTimedLeaderboard"
+	QuizService_CreateQuestion_FullMethodName      = "/quiz.QuizService/CreateQuestion"
+	QuizService_UpdateQuestion_FullMethodName      = "/quiz.QuizService/UpdateQuestion"
+	QuizService_ArchiveQuestion_FullMethodName     = "/quiz.QuizService/ArchiveQuestion"
+	QuizService_ListQuestions_FullMethodName       = "/quiz.QuizService/ListQuestions"
+	QuizService_ImportQuestions_FullMethodName     = "/quiz.QuizService/ImportQuestions"
+	QuizService_ExportQuestions_FullMethodName     = "/quiz.QuizService/ExportQuestions"
+)
+
+// QuizServiceClient is the client API for QuizService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QuizServiceClient interface {
+	GetRandomQuestion(ctx context.Context, in *GetRandomQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error)
+	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*QuizStats, error)
+	GetAnswerHistory(ctx context.Context, in *GetAnswerHistoryRequest, opts ...grpc.CallOption) (*GetAnswerHistoryResponse, error)
+	GetQuestionById(ctx context.Context, in *GetQuestionByIdRequest, opts ...grpc.CallOption) (*Question, error)
+	GetReviewQuestion(ctx context.Context, in *GetReviewQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	RefillLives(ctx context.Context, in *RefillLivesRequest, opts ...grpc.CallOption) (*RefillLivesResponse, error)
+	StartTimedSession(ctx context.Context, in *StartTimedSessionRequest, opts ...grpc.CallOption) (*TimedSession, error)
+	SubmitTimedAnswer(ctx context.Context, in *SubmitTimedAnswerRequest, opts ...grpc.CallOption) (*TimedAnswerResult, error)
+	FinishTimedSession(ctx context.Context, in *FinishTimedSessionRequest, opts ...grpc.CallOption) (*TimedSessionResult, error)
+	GetTimedLeaderboard(ctx context.Context, in *GetTimedLeaderboardRequest, opts ...grpc.CallOption) (*TimedLeaderboard, error)
+	CreateQuestion(ctx context.Context, in *CreateQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error)
+	UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error)
+	ArchiveQuestion(ctx context.Context, in *ArchiveQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error)
+	ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error)
+	ImportQuestions(ctx context.Context, in *ImportQuestionsRequest, opts ...grpc.CallOption) (*ImportQuestionsResponse, error)
+	ExportQuestions(ctx context.Context, in *ExportQuestionsRequest, opts ...grpc.CallOption) (*ExportQuestionsResponse, error)
+}
+
+type quizServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuizServiceClient(cc grpc.ClientConnInterface) QuizServiceClient {
+	return &quizServiceClient{cc}
+}
+
+func (c *quizServiceClient) GetRandomQuestion(ctx context.Context, in *GetRandomQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuizService_GetRandomQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitAnswerResponse)
+	err := c.cc.Invoke(ctx, QuizService_SubmitAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*QuizStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuizStats)
+	err := c.cc.Invoke(ctx, QuizService_GetUserStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetAnswerHistory(ctx context.Context, in *GetAnswerHistoryRequest, opts ...grpc.CallOption) (*GetAnswerHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnswerHistoryResponse)
+	err := c.cc.Invoke(ctx, QuizService_GetAnswerHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetQuestionById(ctx context.Context, in *GetQuestionByIdRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuizService_GetQuestionById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetReviewQuestion(ctx context.Context, in *GetReviewQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuizService_GetReviewQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) RefillLives(ctx context.Context, in *RefillLivesRequest, opts ...grpc.CallOption) (*RefillLivesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefillLivesResponse)
+	err := c.cc.Invoke(ctx, QuizService_RefillLives_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) StartTimedSession(ctx context.Context, in *StartTimedSessionRequest, opts ...grpc.CallOption) (*TimedSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimedSession)
+	err := c.cc.Invoke(ctx, QuizService_StartTimedSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) SubmitTimedAnswer(ctx context.Context, in *SubmitTimedAnswerRequest, opts ...grpc.CallOption) (*TimedAnswerResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimedAnswerResult)
+	err := c.cc.Invoke(ctx, QuizService_SubmitTimedAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) FinishTimedSession(ctx context.Context, in *FinishTimedSessionRequest, opts ...grpc.CallOption) (*TimedSessionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimedSessionResult)
+	err := c.cc.Invoke(ctx, QuizService_FinishTimedSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetTimedLeaderboard(ctx context.Context, in *GetTimedLeaderboardRequest, opts ...grpc.CallOption) (*TimedLeaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimedLeaderboard)
+	err := c.cc.Invoke(ctx, QuizService_GetTimedLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) CreateQuestion(ctx context.Context, in *CreateQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuizQuestion)
+	err := c.cc.Invoke(ctx, QuizService_CreateQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuizQuestion)
+	err := c.cc.Invoke(ctx, QuizService_UpdateQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ArchiveQuestion(ctx context.Context, in *ArchiveQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuizQuestion)
+	err := c.cc.Invoke(ctx, QuizService_ArchiveQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuestionsResponse)
+	err := c.cc.Invoke(ctx, QuizService_ListQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ImportQuestions(ctx context.Context, in *ImportQuestionsRequest, opts ...grpc.CallOption) (*ImportQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportQuestionsResponse)
+	err := c.cc.Invoke(ctx, QuizService_ImportQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ExportQuestions(ctx context.Context, in *ExportQuestionsRequest, opts ...grpc.CallOption) (*ExportQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportQuestionsResponse)
+	err := c.cc.Invoke(ctx, QuizService_ExportQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuizServiceServer is the server API for QuizService service.
+// All implementations must embed UnimplementedQuizServiceServer
+// for forward compatibility.
+type QuizServiceServer interface {
+	GetRandomQuestion(context.Context, *GetRandomQuestionRequest) (*Question, error)
+	SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error)
+	GetUserStats(context.Context, *GetUserStatsRequest) (*QuizStats, error)
+	GetAnswerHistory(context.Context, *GetAnswerHistoryRequest) (*GetAnswerHistoryResponse, error)
+	GetQuestionById(context.Context, *GetQuestionByIdRequest) (*Question, error)
+	GetReviewQuestion(context.Context, *GetReviewQuestionRequest) (*Question, error)
+	RefillLives(context.Context, *RefillLivesRequest) (*RefillLivesResponse, error)
+	StartTimedSession(context.Context, *StartTimedSessionRequest) (*TimedSession, error)
+	SubmitTimedAnswer(context.Context, *SubmitTimedAnswerRequest) (*TimedAnswerResult, error)
+	FinishTimedSession(context.Context, *FinishTimedSessionRequest) (*TimedSessionResult, error)
+	GetTimedLeaderboard(context.Context, *GetTimedLeaderboardRequest) (*TimedLeaderboard, error)
+	CreateQuestion(context.Context, *CreateQuestionRequest) (*QuizQuestion, error)
+	UpdateQuestion(context.Context, *UpdateQuestionRequest) (*QuizQuestion, error)
+	ArchiveQuestion(context.Context, *ArchiveQuestionRequest) (*QuizQuestion, error)
+	ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error)
+	ImportQuestions(context.Context, *ImportQuestionsRequest) (*ImportQuestionsResponse, error)
+	ExportQuestions(context.Context, *ExportQuestionsRequest) (*ExportQuestionsResponse, error)
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+// UnimplementedQuizServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuizServiceServer struct{}
+
+func (UnimplementedQuizServiceServer) GetRandomQuestion(context.Context, *GetRandomQuestionRequest) (*Question, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRandomQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitAnswer not implemented")
+}
+func (UnimplementedQuizServiceServer) GetUserStats(context.Context, *GetUserStatsRequest) (*QuizStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserStats not implemented")
+}
+func (UnimplementedQuizServiceServer) GetAnswerHistory(context.Context, *GetAnswerHistoryRequest) (*GetAnswerHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAnswerHistory not implemented")
+}
+func (UnimplementedQuizServiceServer) GetQuestionById(context.Context, *GetQuestionByIdRequest) (*Question, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuestionById not implemented")
+}
+func (UnimplementedQuizServiceServer) GetReviewQuestion(context.Context, *GetReviewQuestionRequest) (*Question, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReviewQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) RefillLives(context.Context, *RefillLivesRequest) (*RefillLivesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefillLives not implemented")
+}
+func (UnimplementedQuizServiceServer) StartTimedSession(context.Context, *StartTimedSessionRequest) (*TimedSession, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartTimedSession not implemented")
+}
+func (UnimplementedQuizServiceServer) SubmitTimedAnswer(context.Context, *SubmitTimedAnswerRequest) (*TimedAnswerResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitTimedAnswer not implemented")
+}
+func (UnimplementedQuizServiceServer) FinishTimedSession(context.Context, *FinishTimedSessionRequest) (*TimedSessionResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishTimedSession not implemented")
+}
+func (UnimplementedQuizServiceServer) GetTimedLeaderboard(context.Context, *GetTimedLeaderboardRequest) (*TimedLeaderboard, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTimedLeaderboard not implemented")
+}
+func (UnimplementedQuizServiceServer) CreateQuestion(context.Context, *CreateQuestionRequest) (*QuizQuestion, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) UpdateQuestion(context.Context, *UpdateQuestionRequest) (*QuizQuestion, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) ArchiveQuestion(context.Context, *ArchiveQuestionRequest) (*QuizQuestion, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListQuestions not implemented")
+}
+func (UnimplementedQuizServiceServer) ImportQuestions(context.Context, *ImportQuestionsRequest) (*ImportQuestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportQuestions not implemented")
+}
+func (UnimplementedQuizServiceServer) ExportQuestions(context.Context, *ExportQuestionsRequest) (*ExportQuestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportQuestions not implemented")
+}
+func (UnimplementedQuizServiceServer) mustEmbedUnimplementedQuizServiceServer() {}
+func (UnimplementedQuizServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeQuizServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuizServiceServer will
+// result in compilation errors.
+type UnsafeQuizServiceServer interface {
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+func RegisterQuizServiceServer(s grpc.ServiceRegistrar, srv QuizServiceServer) {
+	// If the following call panics, it indicates UnimplementedQuizServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuizService_ServiceDesc, srv)
+}
+
+func _QuizService_GetRandomQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRandomQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetRandomQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetRandomQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetRandomQuestion(ctx, req.(*GetRandomQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_SubmitAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).SubmitAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_SubmitAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).SubmitAnswer(ctx, req.(*SubmitAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetUserStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetUserStats(ctx, req.(*GetUserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetAnswerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnswerHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetAnswerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetAnswerHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetAnswerHistory(ctx, req.(*GetAnswerHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetQuestionById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetQuestionById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetQuestionById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetQuestionById(ctx, req.(*GetQuestionByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetReviewQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetReviewQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetReviewQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetReviewQuestion(ctx, req.(*GetReviewQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_RefillLives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefillLivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).RefillLives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_RefillLives_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).RefillLives(ctx, req.(*RefillLivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_StartTimedSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTimedSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).StartTimedSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_StartTimedSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).StartTimedSession(ctx, req.(*StartTimedSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_SubmitTimedAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTimedAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).SubmitTimedAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_SubmitTimedAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).SubmitTimedAnswer(ctx, req.(*SubmitTimedAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_FinishTimedSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishTimedSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).FinishTimedSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_FinishTimedSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).FinishTimedSession(ctx, req.(*FinishTimedSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetTimedLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimedLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetTimedLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetTimedLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetTimedLeaderboard(ctx, req.(*GetTimedLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_CreateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).CreateQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_CreateQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).CreateQuestion(ctx, req.(*CreateQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_UpdateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).UpdateQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_UpdateQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).UpdateQuestion(ctx, req.(*UpdateQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ArchiveQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ArchiveQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ArchiveQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ArchiveQuestion(ctx, req.(*ArchiveQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ListQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ListQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ListQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ListQuestions(ctx, req.(*ListQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ImportQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ImportQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ImportQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ImportQuestions(ctx, req.(*ImportQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ExportQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ExportQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ExportQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ExportQuestions(ctx, req.(*ExportQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuizService_ServiceDesc is the grpc.ServiceDesc for QuizService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuizService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quiz.QuizService",
+	HandlerType: (*QuizServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRandomQuestion",
+			Handler:    _QuizService_GetRandomQuestion_Handler,
+		},
+		{
+			MethodName: "SubmitAnswer",
+			Handler:    _QuizService_SubmitAnswer_Handler,
+		},
+		{
+			MethodName: "GetUserStats",
+			Handler:    _QuizService_GetUserStats_Handler,
+		},
+		{
+			MethodName: "GetAnswerHistory",
+			Handler:    _QuizService_GetAnswerHistory_Handler,
+		},
+		{
+			MethodName: "GetQuestionById",
+			Handler:    _QuizService_GetQuestionById_Handler,
+		},
+		{
+			MethodName: "GetReviewQuestion",
+			Handler:    _QuizService_GetReviewQuestion_Handler,
+		},
+		{
+			MethodName: "RefillLives",
+			Handler:    _QuizService_RefillLives_Handler,
+		},
+		{
+			MethodName: "StartTimedSession",
+			Handler:    _QuizService_StartTimedSession_Handler,
+		},
+		{
+			MethodName: "SubmitTimedAnswer",
+			Handler:    _QuizService_SubmitTimedAnswer_Handler,
+		},
+		{
+			MethodName: "FinishTimedSession",
+			Handler:    _QuizService_FinishTimedSession_Handler,
+		},
+		{
+			MethodName: "GetTimedLeaderboard",
+			Handler:    _QuizService_GetTimedLeaderboard_Handler,
+		},
+		{
+			MethodName: "CreateQuestion",
+			Handler:    _QuizService_CreateQuestion_Handler,
+		},
+		{
+			MethodName: "UpdateQuestion",
+			Handler:    _QuizService_UpdateQuestion_Handler,
+		},
+		{
+			MethodName: "ArchiveQuestion",
+			Handler:    _QuizService_ArchiveQuestion_Handler,
+		},
+		{
+			MethodName: "ListQuestions",
+			Handler:    _QuizService_ListQuestions_Handler,
+		},
+		{
+			MethodName: "ImportQuestions",
+			Handler:    _QuizService_ImportQuestions_Handler,
+		},
+		{
+			MethodName: "ExportQuestions",
+			Handler:    _QuizService_ExportQuestions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/quiz.proto",
+}
@@ -1,235 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.6.1
-// - protoc             v3.21.12
-// source: proto/quiz.proto
-
-package pb
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	QuizService_GetRandomQuestion_FullMethodName = "/quiz.QuizService/GetRandomQuestion"
-	QuizService_SubmitAnswer_FullMethodName      = "/quiz.QuizService/SubmitAnswer"
-	QuizService_GetUserStats_FullMethodName      = "/quiz.QuizService/GetUserStats"
-	QuizService_GetQuestionById_FullMethodName   = "/quiz.QuizService/GetQuestionById"
-)
-
-// QuizServiceClient is the client API for QuizService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type QuizServiceClient interface {
-	GetRandomQuestion(ctx context.Context, in *GetRandomQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error)
-	SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error)
-	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*QuizStats, error)
-	GetQuestionById(ctx context.Context, in *GetQuestionByIdRequest, opts ...grpc.CallOption) (*QuizQuestion, error)
-}
-
-type quizServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewQuizServiceClient(cc grpc.ClientConnInterface) QuizServiceClient {
-	return &quizServiceClient{cc}
-}
-
-func (c *quizServiceClient) GetRandomQuestion(ctx context.Context, in *GetRandomQuestionRequest, opts ...grpc.CallOption) (*QuizQuestion, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(QuizQuestion)
-	err := c.cc.Invoke(ctx, QuizService_GetRandomQuestion_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *quizServiceClient) SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SubmitAnswerResponse)
-	err := c.cc.Invoke(ctx, QuizService_SubmitAnswer_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *quizServiceClient) GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*QuizStats, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(QuizStats)
-	err := c.cc.Invoke(ctx, QuizService_GetUserStats_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *quizServiceClient) GetQuestionById(ctx context.Context, in *GetQuestionByIdRequest, opts ...grpc.CallOption) (*QuizQuestion, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(QuizQuestion)
-	err := c.cc.Invoke(ctx, QuizService_GetQuestionById_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// QuizServiceServer is the server API for QuizService service.
-// All implementations must embed UnimplementedQuizServiceServer
-// for forward compatibility.
-type QuizServiceServer interface {
-	GetRandomQuestion(context.Context, *GetRandomQuestionRequest) (*QuizQuestion, error)
-	SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error)
-	GetUserStats(context.Context, *GetUserStatsRequest) (*QuizStats, error)
-	GetQuestionById(context.Context, *GetQuestionByIdRequest) (*QuizQuestion, error)
-	mustEmbedUnimplementedQuizServiceServer()
-}
-
-// UnimplementedQuizServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedQuizServiceServer struct{}
-
-func (UnimplementedQuizServiceServer) GetRandomQuestion(context.Context, *GetRandomQuestionRequest) (*QuizQuestion, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetRandomQuestion not implemented")
-}
-func (UnimplementedQuizServiceServer) SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SubmitAnswer not implemented")
-}
-func (UnimplementedQuizServiceServer) GetUserStats(context.Context, *GetUserStatsRequest) (*QuizStats, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetUserStats not implemented")
-}
-func (UnimplementedQuizServiceServer) GetQuestionById(context.Context, *GetQuestionByIdRequest) (*QuizQuestion, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetQuestionById not implemented")
-}
-func (UnimplementedQuizServiceServer) mustEmbedUnimplementedQuizServiceServer() {}
-func (UnimplementedQuizServiceServer) testEmbeddedByValue()                     {}
-
-// UnsafeQuizServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to QuizServiceServer will
-// result in compilation errors.
-type UnsafeQuizServiceServer interface {
-	mustEmbedUnimplementedQuizServiceServer()
-}
-
-func RegisterQuizServiceServer(s grpc.ServiceRegistrar, srv QuizServiceServer) {
-	// If the following call panics, it indicates UnimplementedQuizServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&QuizService_ServiceDesc, srv)
-}
-
-func _QuizService_GetRandomQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRandomQuestionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(QuizServiceServer).GetRandomQuestion(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: QuizService_GetRandomQuestion_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuizServiceServer).GetRandomQuestion(ctx, req.(*GetRandomQuestionRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _QuizService_SubmitAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SubmitAnswerRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(QuizServiceServer).SubmitAnswer(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: QuizService_SubmitAnswer_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuizServiceServer).SubmitAnswer(ctx, req.(*SubmitAnswerRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _QuizService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(QuizServiceServer).GetUserStats(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: QuizService_GetUserStats_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuizServiceServer).GetUserStats(ctx, req.(*GetUserStatsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _QuizService_GetQuestionById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetQuestionByIdRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(QuizServiceServer).GetQuestionById(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: QuizService_GetQuestionById_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuizServiceServer).GetQuestionById(ctx, req.(*GetQuestionByIdRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// QuizService_ServiceDesc is the grpc.ServiceDesc for QuizService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var QuizService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "quiz.QuizService",
-	HandlerType: (*QuizServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetRandomQuestion",
-			Handler:    _QuizService_GetRandomQuestion_Handler,
-		},
-		{
-			MethodName: "SubmitAnswer",
-			Handler:    _QuizService_SubmitAnswer_Handler,
-		},
-		{
-			MethodName: "GetUserStats",
-			Handler:    _QuizService_GetUserStats_Handler,
-		},
-		{
-			MethodName: "GetQuestionById",
-			Handler:    _QuizService_GetQuestionById_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/quiz.proto",
-}
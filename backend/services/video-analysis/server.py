@@ -8,6 +8,7 @@ import uuid
 
 from generated import video_analysis_pb2, video_analysis_pb2_grpc
 from kafka_producer import KafkaEventProducer
+from user_data import UserDataStore, start_export_server, start_user_deleted_consumer
 from deepfake_detector import DeepfakeDetector
 
 logging.basicConfig(level=logging.INFO)
@@ -39,7 +40,13 @@ class VideoAnalysisService(video_analysis_pb2_grpc.VideoAnalysisServiceServicer)
 
         # Save video and start analysis
         video_path = self._save_video(task_id, video_chunks)
-        self.tasks[task_id] = {"status": "UPLOADING", "logs": []}
+        self.tasks[task_id] = {
+            "status": "UPLOADING",
+            "logs": [],
+            "user_id": metadata.user_id,
+            "video_path": video_path,
+            "created_at": datetime.now().isoformat(),
+        }
         
         # Emit event
         self.kafka_producer.emit("video.uploaded", {
@@ -142,9 +149,14 @@ class VideoAnalysisService(video_analysis_pb2_grpc.VideoAnalysisServiceServicer)
 
 def serve():
     server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
-    video_analysis_pb2_grpc.add_VideoAnalysisServiceServicer_to_server(
-        VideoAnalysisService(), server
-    )
+    service = VideoAnalysisService()
+    video_analysis_pb2_grpc.add_VideoAnalysisServiceServicer_to_server(service, server)
+
+    # 계정 삭제(user.deleted) 시 분석 데이터 삭제, /me/export 로 개인 데이터 제공
+    user_data = UserDataStore(service.tasks)
+    start_user_deleted_consumer(user_data)
+    start_export_server(user_data)
+
     server.add_insecure_port("[::]:50054")
     logger.info("Video Analysis Service started on port 50054")
     server.start()
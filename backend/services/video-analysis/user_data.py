@@ -0,0 +1,155 @@
+import json
+import logging
+import os
+import threading
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+
+import jwt
+import psycopg2
+from confluent_kafka import Consumer
+
+logger = logging.getLogger(__name__)
+
+# 개발용 기본값. 다른 서비스와 같은 JWT_SECRET 을 써야 한다.
+DEV_JWT_SECRET = "dev_jwt_secret_change_in_production"
+EXPORT_PORT = 8080
+
+
+class UserDataStore:
+    """사용자 한 명의 분석 작업(메모리, 영상 파일, video_analysis 스키마)을 내보내거나 삭제한다."""
+
+    def __init__(self, tasks: dict):
+        self.tasks = tasks
+        self.database_url = os.getenv("DATABASE_URL")
+
+    def export(self, user_id: str) -> dict:
+        tasks = [
+            {
+                "task_id": task_id,
+                "status": task["status"],
+                "created_at": task.get("created_at"),
+                "result": task.get("result"),
+            }
+            for task_id, task in list(self.tasks.items())
+            if task.get("user_id") == user_id
+        ]
+        stored = []
+        if self.database_url:
+            with psycopg2.connect(self.database_url) as conn, conn.cursor() as cur:
+                cur.execute(
+                    """SELECT t.id::text, t.video_url, t.status, t.created_at,
+                              r.verdict, r.confidence_score::float, r.model_version
+                       FROM video_analysis.tasks t
+                       LEFT JOIN video_analysis.results r ON r.task_id = t.id
+                       WHERE t.user_id = %s ORDER BY t.created_at""",
+                    (user_id,),
+                )
+                for row in cur.fetchall():
+                    stored.append({
+                        "task_id": row[0],
+                        "video_url": row[1],
+                        "status": row[2],
+                        "created_at": row[3].isoformat() if row[3] else None,
+                        "verdict": row[4],
+                        "confidence_score": row[5],
+                        "model_version": row[6],
+                    })
+        return {"tasks": tasks, "stored_tasks": stored}
+
+    def delete(self, user_id: str):
+        """다시 실행해도 안전하다. 결과는 ON DELETE CASCADE 로 함께 지워진다."""
+        for task_id, task in list(self.tasks.items()):
+            if task.get("user_id") != user_id:
+                continue
+            video_path = task.get("video_path")
+            if video_path and os.path.exists(video_path):
+                os.remove(video_path)
+            self.tasks.pop(task_id, None)
+
+        if self.database_url:
+            with psycopg2.connect(self.database_url) as conn, conn.cursor() as cur:
+                cur.execute("DELETE FROM video_analysis.tasks WHERE user_id = %s", (user_id,))
+
+
+def start_user_deleted_consumer(store: UserDataStore):
+    brokers = os.getenv("KAFKA_BROKERS", "kafka:29092")
+    consumer = Consumer({
+        "bootstrap.servers": brokers,
+        "group.id": "video-analysis-service",
+        "auto.offset.reset": "earliest",
+        "enable.auto.commit": False,
+    })
+    consumer.subscribe(["pawfiler-events"])
+
+    def run():
+        while True:
+            msg = consumer.poll(1.0)
+            if msg is None:
+                continue
+            if msg.error():
+                logger.error(f"Kafka consumer error: {msg.error()}")
+                continue
+            try:
+                event = json.loads(msg.value())
+            except ValueError:
+                logger.warning(f"Ignoring malformed event: {msg.value()!r}")
+                consumer.commit(message=msg)
+                continue
+            if event.get("event_type") == "user.deleted":
+                user_id = (event.get("payload") or {}).get("user_id")
+                if not user_id:
+                    logger.warning(f"Ignoring malformed user.deleted event: {event}")
+                else:
+                    try:
+                        store.delete(user_id)
+                    except Exception as e:
+                        # 커밋하지 않고 같은 메시지를 다시 처리한다.
+                        logger.error(f"Failed to delete video analysis data for user {user_id}: {e}")
+                        consumer.seek(msg)
+                        continue
+                    logger.info(f"Deleted video analysis data for user {user_id}")
+            consumer.commit(message=msg)
+
+    threading.Thread(target=run, daemon=True).start()
+
+
+def start_export_server(store: UserDataStore):
+    """auth 서비스가 개인 데이터 내보내기 때 호출하는 GET /me/export 를 띄운다."""
+    secret = os.getenv("JWT_SECRET", DEV_JWT_SECRET)
+
+    class ExportHandler(BaseHTTPRequestHandler):
+        def do_GET(self):
+            if self.path != "/me/export":
+                self.send_error(404)
+                return
+            scheme, _, token = self.headers.get("Authorization", "").partition(" ")
+            if scheme.lower() != "bearer" or not token:
+                self.send_error(401, "missing bearer token")
+                return
+            try:
+                claims = jwt.decode(
+                    token.strip(), secret, algorithms=["HS256"],
+                    options={"require": ["exp", "sub"]},
+                )
+            except jwt.InvalidTokenError:
+                self.send_error(401, "invalid token")
+                return
+
+            try:
+                data = store.export(claims["sub"])
+            except Exception as e:
+                logger.error(f"Video analysis data export for user {claims['sub']} failed: {e}")
+                self.send_error(500)
+                return
+
+            body = json.dumps(data, ensure_ascii=False).encode("utf-8")
+            self.send_response(200)
+            self.send_header("Content-Type", "application/json")
+            self.send_header("Cache-Control", "no-store")
+            self.send_header("Content-Length", str(len(body)))
+            self.end_headers()
+            self.wfile.write(body)
+
+    server = ThreadingHTTPServer(("", EXPORT_PORT), ExportHandler)
+    threading.Thread(target=server.serve_forever, daemon=True).start()
+    logger.info(f"Personal data export listening on port {EXPORT_PORT}")
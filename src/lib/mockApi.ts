@@ -54,7 +54,12 @@ const MOCK_USER: UserProfile = {
   createdAt: "2025-09-15T00:00:00Z",
 };
 
-const MOCK_QUIZ_QUESTIONS: QuizQuestion[] = [
+type MockQuizQuestion = Omit<QuizQuestion, "attemptId" | "attemptExpiresIn"> & {
+  correctIndex: number;
+  explanation: string;
+};
+
+const MOCK_QUIZ_QUESTIONS: MockQuizQuestion[] = [
   {
     id: "q1",
     videoUrl: "",
@@ -137,7 +142,9 @@ export async function fetchUserProfile(token: string): Promise<UserProfile> {
 export async function fetchQuizQuestion(token: string): Promise<QuizQuestion> {
   withAuth(token);
   await delay(500, 800);
-  return MOCK_QUIZ_QUESTIONS[Math.floor(Math.random() * MOCK_QUIZ_QUESTIONS.length)];
+  const { correctIndex: _correctIndex, explanation: _explanation, ...question } =
+    MOCK_QUIZ_QUESTIONS[Math.floor(Math.random() * MOCK_QUIZ_QUESTIONS.length)];
+  return { ...question, attemptId: uuid(), attemptExpiresIn: 1800 };
 }
 
 export async function submitQuizAnswer(token: string, req: QuizSubmitRequest): Promise<QuizSubmitResponse> {
@@ -151,13 +158,38 @@ export async function submitQuizAnswer(token: string, req: QuizSubmitRequest): P
     coinsEarned: correct ? 25 : 0,
     explanation: q?.explanation ?? "",
     streakCount: correct ? 3 : 0,
+    correctIndex: q?.correctIndex ?? -1,
   };
 }
 
 export async function fetchQuizStats(token: string): Promise<QuizStats> {
   withAuth(token);
   await delay(300, 500);
-  return { totalAnswered: 47, correctRate: 0.78, currentStreak: 3, bestStreak: 12, lives: 2 };
+  return {
+    totalAnswered: 47,
+    correctRate: 0.78,
+    currentStreak: 3,
+    bestStreak: 12,
+    lives: 2,
+    rating: 1040,
+    reviewsDue: 4,
+    maxLives: 3,
+    nextLifeIn: 1260,
+    correctCount: 37,
+    byDifficulty: [
+      { key: "easy", answered: 20, correct: 18, correctRate: 0.9 },
+      { key: "hard", answered: 7, correct: 4, correctRate: 4 / 7 },
+      { key: "medium", answered: 20, correct: 15, correctRate: 0.75 },
+    ],
+    byCategory: [
+      { key: "authentic", answered: 15, correct: 13, correctRate: 13 / 15 },
+      { key: "face_swap", answered: 32, correct: 24, correctRate: 0.75 },
+    ],
+    daily: Array.from({ length: 7 }, (_, i) => {
+      const d = new Date(Date.now() - (6 - i) * 86_400_000);
+      return { date: d.toISOString().slice(0, 10), answered: i + 3, correct: i + 2 };
+    }),
+  };
 }
 
 // --------------- Community Service ---------------
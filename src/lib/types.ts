@@ -39,19 +39,24 @@ export interface UserProfile {
 }
 
 // --- Quiz Service ---
+// The answer is not sent with the question; it comes back in
+// QuizSubmitResponse. attemptId must be passed back when answering.
 export interface QuizQuestion {
   id: string;
   videoUrl: string;
   thumbnailEmoji: string;
   options: string[];
-  correctIndex: number;
-  explanation: string;
   difficulty: "easy" | "medium" | "hard";
+  attemptId: string;
+  attemptExpiresIn: number;
 }
 
 export interface QuizSubmitRequest {
   questionId: string;
+  attemptId: string;
   selectedIndex: number;
+  // Retries with the same key return the first response; defaults to attemptId.
+  idempotencyKey?: string;
 }
 
 export interface QuizSubmitResponse {
@@ -60,6 +65,7 @@ export interface QuizSubmitResponse {
   coinsEarned: number;
   explanation: string;
   streakCount: number;
+  correctIndex: number;
 }
 
 export interface QuizStats {
@@ -68,6 +74,71 @@ export interface QuizStats {
   currentStreak: number;
   bestStreak: number;
   lives: number;
+  rating: number;
+  reviewsDue: number;
+  maxLives: number;
+  nextLifeIn: number;
+  correctCount: number;
+  byDifficulty: StatsBreakdown[];
+  byCategory: StatsBreakdown[];
+  daily: DailyStats[];
+}
+
+export interface StatsBreakdown {
+  key: string;
+  answered: number;
+  correct: number;
+  correctRate: number;
+}
+
+export interface DailyStats {
+  date: string;
+  answered: number;
+  correct: number;
+}
+
+// Timed challenge. Questions carry no attempt; answers go to the session and
+// must arrive within timeLimitMs of the question being served.
+export interface TimedSession {
+  sessionId: string;
+  question: QuizQuestion;
+  questionNumber: number;
+  questionCount: number;
+  timeLimitMs: number;
+  score: number;
+}
+
+export interface TimedAnswerRequest {
+  sessionId: string;
+  questionId: string;
+  selectedIndex: number;
+}
+
+// Either next or result is set.
+export interface TimedAnswerResult {
+  correct: boolean;
+  timedOut: boolean;
+  correctIndex: number;
+  explanation: string;
+  elapsedMs: number;
+  points: number;
+  streak: number;
+  score: number;
+  next?: TimedSession;
+  result?: TimedSessionResult;
+}
+
+export interface TimedSessionResult {
+  sessionId: string;
+  score: number;
+  questionCount: number;
+  answeredCount: number;
+  correctCount: number;
+  bestStreak: number;
+  xpEarned: number;
+  coinsEarned: number;
+  startedAt: string;
+  finishedAt: string;
 }
 
 // --- Community Service ---
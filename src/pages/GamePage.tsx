@@ -39,7 +39,7 @@ const GamePage = () => {
     if (selectedIndex === null || !question || !token) return;
     setSubmitting(true);
     try {
-      const res = await submitQuizAnswer({ questionId: question.id, selectedIndex });
+      const res = await submitQuizAnswer({ questionId: question.id, attemptId: question.attemptId, selectedIndex });
       setResult(res);
       if (res.correct) {
         setScore((s) => s + res.coinsEarned);
@@ -88,7 +88,7 @@ const GamePage = () => {
               {question.options.map((opt, i) => {
                 const isSelected = selectedIndex === i;
                 const showResult = result !== null;
-                const isCorrect = showResult && i === question.correctIndex;
+                const isCorrect = showResult && i === result.correctIndex;
                 const isWrong = showResult && isSelected && !result.correct;
 
                 return (